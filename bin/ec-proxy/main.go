@@ -4,38 +4,53 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"path"
 	"path/filepath"
+	"strings"
 	"syscall"
 
 	"github.com/markelog/eclectica/cmd/print"
-	"github.com/markelog/eclectica/console"
+	"github.com/markelog/eclectica/daemon"
 	"github.com/markelog/eclectica/io"
 	"github.com/markelog/eclectica/plugins"
 	"github.com/markelog/eclectica/variables"
 	"github.com/markelog/eclectica/versions"
 )
 
-// Pipe results of command execution to parent and
-// pass environment variables from language plugin
-func setCmd(cmd *exec.Cmd, language, version string) {
+// Compose environment variables from the language plugin
+func getEnvironment(language, version string) []string {
 	environment, err := plugins.New(&plugins.Args{
 		Language: language,
 		Version:  version,
 	}).Environment()
 	print.Error(err)
 
-	if len(environment) > 0 {
-		env := os.Environ()
-		env = append(env, environment...)
+	return mergeEnvironment(os.Environ(), environment)
+}
+
+// Merge variables of the plugin into the inherited ones, exec doesn't remove
+// duplicates and the first one wins on lookup, so they are replaced in place
+func mergeEnvironment(inherited, environment []string) []string {
+	var (
+		result = []string{}
+		index  = map[string]int{}
+	)
+
+	for _, list := range [][]string{inherited, environment} {
+		for _, variable := range list {
+			name := strings.SplitN(variable, "=", 2)[0]
 
-		cmd.Env = env
+			if i, ok := index[name]; ok {
+				result[i] = variable
+				continue
+			}
+
+			index[name] = len(result)
+			result = append(result, variable)
+		}
 	}
 
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Stdin = os.Stdin
+	return result
 }
 
 // Get relative path to the dot file
@@ -49,16 +64,32 @@ func getRelativePath(dotPath string) string {
 	return "./" + filepath.Join(dir, filepath.Base(dotPath))
 }
 
-func getVersion(language string) (version, dotPath string) {
-	plugin := plugins.New(&plugins.Args{
+// Ask the daemon first, if it's not there, look for the dot file ourselves
+func resolveVersion(language string) (version, dotPath string) {
+	cwd, err := os.Getwd()
+	print.Error(err)
+
+	version, dotPath, err = daemon.Resolve(language, cwd)
+	if err == nil {
+		return
+	}
+
+	version, dotPath, err = getPlugin(language).LocalVersion(cwd)
+	print.Error(err)
+
+	return
+}
+
+func getPlugin(language string) *plugins.Plugin {
+	return plugins.New(&plugins.Args{
 		Language: language,
 	})
-	dotFiles := plugin.Dots()
+}
 
-	version, dotPath, err := io.GetVersion(dotFiles)
-	print.Error(err)
+func getVersion(language string) (version, dotPath string) {
+	version, dotPath = resolveVersion(language)
 
-	if version == "current" {
+	if version == "current" || version == plugins.System {
 		return
 	}
 
@@ -66,7 +97,7 @@ func getVersion(language string) (version, dotPath string) {
 		return version, dotPath
 	}
 
-	vers := plugin.List()
+	vers := getPlugin(language).List()
 	if len(vers) == 0 {
 		notInstalled(version, dotPath)
 	}
@@ -96,11 +127,85 @@ func notInstalled(version, dotPath string) {
 	print.Error(err)
 }
 
+func notAvailable(name, language, version string) {
+	if version == "current" {
+		version = variables.CurrentVersion(language)
+	}
+
+	err := errors.New(
+		"\"" + name + "\" is not available for " + language + "@" + version +
+			", it was installed only for some other version",
+	)
+
+	print.Error(err)
+}
+
+// Check if OS installation of the language should be used, i.e. it was either
+// explicitly asked for or eclectica has no version for it
+func isSystem(language, version string) bool {
+	if version == plugins.System {
+		return true
+	}
+
+	if version != "current" {
+		return false
+	}
+
+	_, err := os.Stat(variables.Path(language))
+
+	return os.IsNotExist(err)
+}
+
+// Execute binary provided by the OS, avoiding the proxies along the way
+func system(name, language string) {
+	binPath, err := io.LookPath(name, variables.Base(), variables.DefaultInstall)
+	if err != nil {
+		print.Error(errors.New(
+			"\"" + name + "\" is not provided by the system and no " + language +
+				" version was chosen in eclectica",
+		))
+	}
+
+	if variables.IsDebug() {
+		fmt.Println("bin path: " + binPath)
+	}
+
+	args := []string{binPath}
+	args = append(args, os.Args[1:]...)
+
+	execute(binPath, args, os.Environ())
+}
+
+// Replace the proxy with the actual binary, so there would be no
+// middleman in the process tree which might eat signals and exit statuses
+func execute(binPath string, args, env []string) {
+	err := syscall.Exec(binPath, args, env)
+
+	// If exec didn't work out for some reason, run the binary as a child
+	state, errRun := run(binPath, args, env)
+	if errRun == nil {
+		exit(state)
+	}
+
+	print.Error(err)
+}
+
 func main() {
 	_, name := path.Split(os.Args[0])
 
 	language := plugins.SearchBin(name)
+	if language == "" {
+		print.Error(errors.New(
+			"eclectica doesn't know about \"" + name + "\", try to execute \"ec rehash\"",
+		))
+	}
+
 	version, dotPath := getVersion(language)
+
+	if isSystem(language, version) {
+		system(name, language)
+	}
+
 	base := variables.Home()
 
 	pathPart := filepath.Join(base, language, version)
@@ -111,24 +216,29 @@ func main() {
 	}
 
 	if _, err := os.Stat(binPath); os.IsNotExist(err) {
+
+		// Version is there, but this executable was installed only for some other version
+		if _, errStat := os.Stat(pathPart); errStat == nil {
+			notAvailable(name, language, version)
+		}
+
 		notInstalled(version, dotPath)
 	}
 
 	args := []string{binPath}
 	args = append(args, os.Args[1:]...)
 
-	cmd := console.Get(args)
-
-	setCmd(cmd, language, version)
+	env := getEnvironment(language, version)
 
-	err := cmd.Run()
+	// Package managers might install new executables, so we will need
+	// to create proxies for them after they are done
+	if needsRehash(name, os.Args[1:]) {
+		state, err := run(binPath, args, env)
+		print.Error(err)
 
-	// Pass the exit code back
-	if sysErr, ok := err.(*exec.ExitError); ok {
-		if status, ok := sysErr.Sys().(syscall.WaitStatus); ok {
-			os.Exit(status.ExitStatus())
-		} else {
-			os.Exit(1)
-		}
+		rehash(language)
+		exit(state)
 	}
+
+	execute(binPath, args, env)
 }
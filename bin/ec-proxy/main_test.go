@@ -0,0 +1,119 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/markelog/monkey"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/markelog/eclectica/index"
+	"github.com/markelog/eclectica/variables"
+)
+
+var _ = Describe("ec-proxy", func() {
+	Describe("getEnvironment", func() {
+		old := os.Getenv("GOROOT")
+
+		BeforeEach(func() {
+			os.Setenv("GOROOT", "/inherited/go")
+		})
+
+		AfterEach(func() {
+			os.Setenv("GOROOT", old)
+		})
+
+		It("should replace inherited variables with the plugin ones", func() {
+			var (
+				env    = getEnvironment("go", "1.21.0")
+				goroot = "GOROOT=" + variables.Path("go", "1.21.0")
+			)
+
+			Expect(env).To(ContainElement(goroot))
+			Expect(env).NotTo(ContainElement("GOROOT=/inherited/go"))
+		})
+
+		It("should pass variables of the plugin to the child", func() {
+			var (
+				env   = getEnvironment("go", "1.21.0")
+				check = `test "$GOROOT" = "` + variables.Path("go", "1.21.0") + `"`
+			)
+
+			state, err := run("/bin/sh", []string{"/bin/sh", "-c", check}, env)
+
+			Expect(err).To(BeNil())
+			Expect(state.ExitCode()).To(Equal(0))
+		})
+	})
+
+	Describe("mergeEnvironment", func() {
+		It("should keep the order and append new variables", func() {
+			result := mergeEnvironment(
+				[]string{"A=1", "B=2", "A=3"},
+				[]string{"B=4", "C=5"},
+			)
+
+			Expect(result).To(Equal([]string{"A=3", "B=4", "C=5"}))
+		})
+	})
+
+	Describe("rehash", func() {
+		var (
+			tmp            string
+			defaultInstall = variables.DefaultInstall
+		)
+
+		BeforeEach(func() {
+			tmp, _ = ioutil.TempDir("", "eclectica-proxy")
+
+			monkey.Patch(variables.Home, func() string {
+				return filepath.Join(tmp, "versions")
+			})
+
+			variables.DefaultInstall = filepath.Join(tmp, "bin")
+			monkey.Patch(index.Path, func() string {
+				return filepath.Join(tmp, "index")
+			})
+
+			bin := filepath.Join(tmp, "versions", "go", "1.21.0", "bin")
+			os.MkdirAll(bin, 0755)
+			os.MkdirAll(variables.DefaultInstall, 0755)
+
+			// Acts like "go install" which puts the executable to GOBIN
+			ioutil.WriteFile(filepath.Join(bin, "go"), []byte(
+				"#!/bin/sh\nprintf '#!/bin/sh\\n' > \"$GOBIN/hello\"\nchmod +x \"$GOBIN/hello\"\n",
+			), 0755)
+		})
+
+		AfterEach(func() {
+			monkey.Unpatch(variables.Home)
+			monkey.Unpatch(index.Path)
+
+			variables.DefaultInstall = defaultInstall
+
+			os.RemoveAll(tmp)
+		})
+
+		It("should create proxy for the executable installed by \"go install\"", func() {
+			var (
+				args   = []string{"install", "example.com/hello@latest"}
+				goPath = filepath.Join(variables.Path("go", "1.21.0"), "bin", "go")
+			)
+
+			Expect(needsRehash("go", args)).To(Equal(true))
+
+			state, err := run(goPath, append([]string{goPath}, args...), getEnvironment("go", "1.21.0"))
+
+			Expect(err).To(BeNil())
+			Expect(state.ExitCode()).To(Equal(0))
+
+			rehash("go")
+
+			_, err = os.Lstat(filepath.Join(variables.DefaultInstall, "hello"))
+			Expect(err).To(BeNil())
+			Expect(index.Language("hello")).To(Equal("go"))
+		})
+	})
+})
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/markelog/eclectica/plugins"
+)
+
+var (
+	// Package managers and their commands which might add or remove executables
+	installers = map[string][]string{
+		"npm":   {"install", "i", "add", "uninstall", "un", "remove", "rm", "r", "link", "ln", "update", "up"},
+		"gem":   {"install", "uninstall", "update"},
+		"pip":   {"install", "uninstall"},
+		"cargo": {"install", "uninstall"},
+		"go":    {"install", "get"},
+		"deno":  {"install", "uninstall"},
+		"bun":   {"add", "a", "remove", "rm", "install", "i", "link", "unlink", "update"},
+	}
+
+	// Like "pip3" or "pip3.12"
+	rPip = regexp.MustCompile(`^pip[\d.]*$`)
+)
+
+// needsRehash checks if executed command might change the set of executables
+func needsRehash(name string, args []string) bool {
+	if rPip.MatchString(name) {
+		name = "pip"
+	}
+
+	commands, ok := installers[name]
+	if ok == false {
+		return false
+	}
+
+	// Flags might have values, like "npm --prefix x install", so the command
+	// is not always the first non-flag argument, extra rehash is harmless though
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			continue
+		}
+
+		for _, command := range commands {
+			if arg == command {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// rehash proxies of the language, failure here shouldn't
+// affect the exit status of the executed command, so ignore the errors
+func rehash(language string) {
+	plugins.New(&plugins.Args{
+		Language: language,
+	}).Rehash()
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+
+	"github.com/markelog/eclectica/cmd/print"
+)
+
+var (
+
+	// Signals which are usually sent to the proxy pid directly (by supervisors and such),
+	// so they are passed to the child as is
+	forward = []os.Signal{
+		syscall.SIGTERM, syscall.SIGHUP, syscall.SIGUSR1, syscall.SIGUSR2,
+	}
+
+	// Terminal sends these to the whole process group, child already got them,
+	// so the proxy should just survive them and wait for the child.
+	// Note: they are caught, not ignored, since ignored signals are inherited by the child
+	ignore = []os.Signal{
+		syscall.SIGINT, syscall.SIGQUIT,
+	}
+)
+
+// run executes binary as a child and forwards the signals to it
+func run(binPath string, args, env []string) (*os.ProcessState, error) {
+	cmd := exec.Command(binPath, args[1:]...)
+
+	cmd.Env = env
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, append(forward, ignore...)...)
+
+	err := cmd.Start()
+	if err != nil {
+		signal.Stop(signals)
+		return nil, err
+	}
+
+	go func() {
+		for sig := range signals {
+			if isForwarded(sig) {
+				cmd.Process.Signal(sig)
+			}
+		}
+	}()
+
+	cmd.Wait()
+	signal.Stop(signals)
+
+	return cmd.ProcessState, nil
+}
+
+func isForwarded(sig os.Signal) bool {
+	for _, forwarded := range forward {
+		if sig == forwarded {
+			return true
+		}
+	}
+
+	return false
+}
+
+// exit reproduces exit status of the child, including death by a signal
+func exit(state *os.ProcessState) {
+	status, ok := state.Sys().(syscall.WaitStatus)
+	if ok == false {
+		os.Exit(state.ExitCode())
+	}
+
+	if status.Signaled() == false {
+		os.Exit(status.ExitStatus())
+	}
+
+	sig := status.Signal()
+
+	// Die from the same signal, so parent would see what really happened
+	signal.Reset(sig)
+	err := syscall.Kill(os.Getpid(), sig)
+	print.Error(err)
+
+	// In case signal didn't kill us, follow the shell convention
+	os.Exit(128 + int(sig))
+}
@@ -4,9 +4,11 @@ import (
 	"github.com/markelog/eclectica/cmd/commands"
 
 	// Commands
+	"github.com/markelog/eclectica/cmd/commands/daemon"
 	"github.com/markelog/eclectica/cmd/commands/install"
 	"github.com/markelog/eclectica/cmd/commands/ls"
 	"github.com/markelog/eclectica/cmd/commands/path"
+	"github.com/markelog/eclectica/cmd/commands/rehash"
 	removeEverything "github.com/markelog/eclectica/cmd/commands/remove-everything"
 	"github.com/markelog/eclectica/cmd/commands/rm"
 	"github.com/markelog/eclectica/cmd/commands/version"
@@ -18,6 +20,8 @@ func main() {
 	commands.Register(ls.Command)
 	commands.Register(version.Command)
 	commands.Register(path.Command)
+	commands.Register(rehash.Command)
+	commands.Register(daemon.Command)
 	commands.Register(removeEverything.Command)
 
 	commands.Execute()
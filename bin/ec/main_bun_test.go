@@ -0,0 +1,94 @@
+package main_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/variables"
+)
+
+var _ = Describe("bun", func() {
+	if shouldRun("bun") == false {
+		return
+	}
+
+	var tmp string
+
+	BeforeEach(func() {
+		tmp, _ = ioutil.TempDir("", "eclectica-bun")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmp)
+	})
+
+	ls := func() string {
+		cmd := Command("go", "run", path, "ls", "bun")
+		cmd.Dir = tmp
+		command, _ := cmd.Output()
+
+		return string(command)
+	}
+
+	It("should install 1.1.3 version", func() {
+		Execute("go", "run", path, "bun@1.1.3")
+
+		command, err := Command("go", "run", path, "ls", "bun").CombinedOutput()
+
+		Expect(strings.Contains(string(command), "♥ 1.1.3")).To(Equal(true))
+		Expect(err).To(BeNil())
+
+		Execute("go", "run", path, "rm", "bun@1.1.3")
+	})
+
+	It("should link bunx", func() {
+		Execute("go", "run", path, "bun@1.1.3")
+
+		link, err := os.Readlink(filepath.Join(variables.Path("bun", "1.1.3"), "bin", "bunx"))
+
+		Expect(err).To(BeNil())
+		Expect(link).To(Equal("bun"))
+
+		_, err = os.Stat(filepath.Join(bins, "bunx"))
+
+		Expect(err).To(BeNil())
+
+		Execute("go", "run", path, "rm", "bun@1.1.3")
+	})
+
+	Describe("package.json", func() {
+		BeforeEach(func() {
+			Execute("go", "run", path, "bun@1.1.0")
+			Execute("go", "run", path, "bun@1.1.3")
+		})
+
+		AfterEach(func() {
+			Execute("go", "run", path, "rm", "bun@1.1.0")
+			Execute("go", "run", path, "rm", "bun@1.1.3")
+		})
+
+		It("should use version from \"packageManager\" field", func() {
+			io.WriteFile(filepath.Join(tmp, "package.json"), `{"packageManager": "bun@1.1.0"}`)
+
+			Expect(ls()).To(ContainSubstring("♥ 1.1.0"))
+		})
+
+		It("should use exact version from \"engines.bun\" field", func() {
+			io.WriteFile(filepath.Join(tmp, "package.json"), `{"engines": {"bun": "1.1.0"}}`)
+
+			Expect(ls()).To(ContainSubstring("♥ 1.1.0"))
+		})
+
+		It("should ignore range in \"engines.bun\" field", func() {
+			io.WriteFile(filepath.Join(tmp, "package.json"), `{"engines": {"bun": "^1.1.0"}}`)
+
+			Expect(ls()).To(ContainSubstring("♥ 1.1.3"))
+		})
+	})
+})
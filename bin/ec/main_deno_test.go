@@ -0,0 +1,80 @@
+package main_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/variables"
+)
+
+var _ = Describe("deno", func() {
+	if shouldRun("deno") == false {
+		return
+	}
+
+	var tmp string
+
+	BeforeEach(func() {
+		tmp, _ = ioutil.TempDir("", "eclectica-deno")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmp)
+	})
+
+	It("should install 1.40.0 version", func() {
+		Execute("go", "run", path, "deno@1.40.0")
+
+		command, err := Command("go", "run", path, "ls", "deno").CombinedOutput()
+
+		Expect(strings.Contains(string(command), "♥ 1.40.0")).To(Equal(true))
+		Expect(err).To(BeNil())
+
+		Execute("go", "run", path, "rm", "deno@1.40.0")
+	})
+
+	It("should use version from .dvmrc", func() {
+		Execute("go", "run", path, "deno@1.39.0")
+		Execute("go", "run", path, "deno@1.40.0")
+
+		io.WriteFile(filepath.Join(tmp, ".dvmrc"), "1.39.0")
+
+		ls := Command("go", "run", path, "ls", "deno")
+		ls.Dir = tmp
+		command, _ := ls.Output()
+
+		Expect(strings.Contains(string(command), "♥ 1.39.0")).To(Equal(true))
+
+		Execute("go", "run", path, "rm", "deno@1.39.0")
+		Execute("go", "run", path, "rm", "deno@1.40.0")
+	})
+
+	It("should install scripts into the version folder", func() {
+		var (
+			script = filepath.Join(tmp, "hello.ts")
+			proxy  = filepath.Join(bins, "hello")
+		)
+
+		defer os.Remove(proxy)
+
+		Execute("go", "run", path, "deno@1.40.0")
+
+		io.WriteFile(script, `console.log("hello")`)
+
+		Execute(filepath.Join(bins, "deno"), "install", "-n", "hello", script)
+
+		_, err := os.Stat(filepath.Join(variables.Path("deno", "1.40.0"), "bin", "hello"))
+		Expect(err).To(BeNil())
+
+		_, err = os.Stat(proxy)
+		Expect(err).To(BeNil())
+
+		Execute("go", "run", path, "rm", "deno@1.40.0")
+	})
+})
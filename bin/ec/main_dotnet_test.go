@@ -0,0 +1,88 @@
+package main_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/markelog/eclectica/io"
+)
+
+var _ = Describe("dotnet", func() {
+	if shouldRun("dotnet") == false {
+		return
+	}
+
+	var tmp string
+
+	BeforeEach(func() {
+		tmp, _ = ioutil.TempDir("", "eclectica-dotnet")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmp)
+	})
+
+	It("should install 8.0.100 version", func() {
+		Execute("go", "run", path, "dotnet@8.0.100")
+
+		command, err := Command("go", "run", path, "ls", "dotnet").CombinedOutput()
+
+		Expect(strings.Contains(string(command), "♥ 8.0.100")).To(Equal(true))
+		Expect(err).To(BeNil())
+
+		Execute("go", "run", path, "rm", "dotnet@8.0.100")
+	})
+
+	It("should install partial version", func() {
+		Execute("go", "run", path, "dotnet@8.0")
+
+		command, err := Command("go", "run", path, "ls", "dotnet").CombinedOutput()
+
+		Expect(strings.Contains(string(command), "♥ 8.0.")).To(Equal(true))
+		Expect(err).To(BeNil())
+	})
+
+	It("should install version locally", func() {
+		Execute("go", "run", path, "dotnet@8.0.100")
+
+		local := Command("go", "run", path, "dotnet@8.0.101", "-l")
+		local.Dir = tmp
+		local.Run()
+
+		Expect(io.Read(filepath.Join(tmp, ".dotnet-version"))).To(Equal("8.0.101"))
+
+		ls := Command("go", "run", path, "ls", "dotnet")
+		ls.Dir = tmp
+		command, _ := ls.Output()
+
+		Expect(strings.Contains(string(command), "♥ 8.0.101")).To(Equal(true))
+
+		command, _ = Command("go", "run", path, "ls", "dotnet").Output()
+
+		Expect(strings.Contains(string(command), "♥ 8.0.100")).To(Equal(true))
+
+		Execute("go", "run", path, "rm", "dotnet@8.0.100")
+		Execute("go", "run", path, "rm", "dotnet@8.0.101")
+	})
+
+	It("should use version from global.json", func() {
+		Execute("go", "run", path, "dotnet@8.0.100")
+		Execute("go", "run", path, "dotnet@8.0.101")
+
+		io.WriteFile(filepath.Join(tmp, "global.json"), `{"sdk": {"version": "8.0.100"}}`)
+
+		ls := Command("go", "run", path, "ls", "dotnet")
+		ls.Dir = tmp
+		command, _ := ls.Output()
+
+		Expect(strings.Contains(string(command), "♥ 8.0.100")).To(Equal(true))
+
+		Execute("go", "run", path, "rm", "dotnet@8.0.100")
+		Execute("go", "run", path, "rm", "dotnet@8.0.101")
+	})
+})
@@ -0,0 +1,31 @@
+package main_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("elixir", func() {
+	if shouldRun("elixir") == false {
+		return
+	}
+
+	It("should install 1.16.0 version for the current erlang", func() {
+		Execute("go", "run", path, "erlang@26.2.1")
+		Execute("go", "run", path, "elixir@1.16.0")
+
+		command, err := Command("go", "run", path, "ls", "elixir").CombinedOutput()
+
+		Expect(strings.Contains(string(command), "♥ 1.16.0")).To(Equal(true))
+		Expect(err).To(BeNil())
+
+		elixir, _ := Command("elixir", "--version").Output()
+
+		Expect(strings.Contains(string(elixir), "Elixir 1.16.0 (compiled with Erlang/OTP 26)")).To(Equal(true))
+
+		Execute("go", "run", path, "rm", "elixir@1.16.0")
+		Execute("go", "run", path, "rm", "erlang@26.2.1")
+	})
+})
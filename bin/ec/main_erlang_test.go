@@ -0,0 +1,54 @@
+package main_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/markelog/eclectica/io"
+)
+
+var _ = Describe("erlang", func() {
+	if shouldRun("erlang") == false {
+		return
+	}
+
+	It("should install 26.2.1 version", func() {
+		Execute("go", "run", path, "erlang@26.2.1")
+
+		command, err := Command("go", "run", path, "ls", "erlang").CombinedOutput()
+
+		Expect(strings.Contains(string(command), "♥ 26.2.1")).To(Equal(true))
+		Expect(err).To(BeNil())
+
+		erl, _ := Command("erl", "-noshell", "-eval", `io:format("~s", [erlang:system_info(otp_release)]), halt().`).Output()
+
+		Expect(string(erl)).To(Equal("26"))
+
+		Execute("go", "run", path, "rm", "erlang@26.2.1")
+	})
+
+	It("should use version from the \".tool-versions\"", func() {
+		pwd, _ := os.Getwd()
+		versionFile := filepath.Join(filepath.Dir(pwd), ".tool-versions")
+
+		Execute("go", "run", path, "erlang@26.2.1")
+		Execute("go", "run", path, "erlang@25.3.0")
+
+		io.WriteFile(versionFile, "erlang 26.2.1")
+
+		command, _ := Command("go", "run", path, "ls", "erlang").Output()
+
+		Expect(strings.Contains(string(command), "♥ 26.2.1")).To(Equal(true))
+
+		err := os.RemoveAll(versionFile)
+
+		Expect(err).To(BeNil())
+
+		Execute("go", "run", path, "rm", "erlang@26.2.1")
+		Execute("go", "run", path, "rm", "erlang@25.3.0")
+	})
+})
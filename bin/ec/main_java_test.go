@@ -0,0 +1,61 @@
+package main_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/markelog/eclectica/io"
+)
+
+var _ = Describe("java", func() {
+	if shouldRun("java") == false {
+		return
+	}
+
+	It("should install temurin-21.0.2 version", func() {
+		Execute("go", "run", path, "java@temurin-21.0.2")
+
+		command, err := Command("go", "run", path, "ls", "java").CombinedOutput()
+
+		Expect(strings.Contains(string(command), "♥ temurin-21.0.2")).To(Equal(true))
+		Expect(err).To(BeNil())
+
+		Execute("go", "run", path, "rm", "java@temurin-21.0.2")
+	})
+
+	It("should install version with the default vendor", func() {
+		Execute("go", "run", path, "java@17.0.10")
+
+		command, err := Command("go", "run", path, "ls", "java").CombinedOutput()
+
+		Expect(strings.Contains(string(command), "♥ temurin-17.0.10")).To(Equal(true))
+		Expect(err).To(BeNil())
+
+		Execute("go", "run", path, "rm", "java@17.0.10")
+	})
+
+	It("should use local version", func() {
+		pwd, _ := os.Getwd()
+		versionFile := filepath.Join(filepath.Dir(pwd), ".java-version")
+
+		Execute("go", "run", path, "java@temurin-17.0.10")
+		Execute("go", "run", path, "java@temurin-21.0.2")
+
+		io.WriteFile(versionFile, "17.0.10")
+
+		command, _ := Command("go", "run", path, "ls", "java").Output()
+
+		Expect(strings.Contains(string(command), "♥ temurin-17.0.10")).To(Equal(true))
+
+		err := os.RemoveAll(versionFile)
+
+		Expect(err).To(BeNil())
+
+		Execute("go", "run", path, "rm", "java@temurin-17.0.10")
+		Execute("go", "run", path, "rm", "java@temurin-21.0.2")
+	})
+})
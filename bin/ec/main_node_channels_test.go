@@ -0,0 +1,36 @@
+package main_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("node channels", func() {
+	if shouldRun("node-channels") == false {
+		return
+	}
+
+	It("should install 21.0.0-rc.1 version", func() {
+		Execute("go", "run", path, "node@21.0.0-rc.1")
+		command, _ := Command("go", "run", path, "ls", "node").Output()
+
+		Expect(strings.Contains(string(command), "♥ 21.0.0-rc.1")).To(Equal(true))
+	})
+
+	It("should execute rc version", func() {
+		version, _ := Command("node", "--version").Output()
+
+		Expect(strings.TrimSpace(string(version))).To(Equal("v21.0.0-rc.1"))
+
+		Execute("go", "run", path, "rm", "node@21.0.0-rc.1")
+	})
+
+	It("should install latest nightly version", func() {
+		Execute("go", "run", path, "node@nightly")
+		command, _ := Command("go", "run", path, "ls", "node").Output()
+
+		Expect(strings.Contains(string(command), "-nightly")).To(Equal(true))
+	})
+})
@@ -0,0 +1,52 @@
+package main_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/markelog/eclectica/io"
+)
+
+var _ = Describe("php", func() {
+	if shouldRun("php") == false {
+		return
+	}
+
+	It("should install 8.2 version", func() {
+		Execute("go", "run", path, "php@8.2")
+
+		command, err := Command("go", "run", path, "ls", "php").CombinedOutput()
+
+		Expect(strings.Contains(string(command), "♥ 8.2.")).To(Equal(true))
+		Expect(err).To(BeNil())
+
+		ini, _ := Command("php", "--ini").Output()
+
+		Expect(strings.Contains(string(ini), filepath.Join("php", "8.2."))).To(Equal(true))
+	})
+
+	It("should use local version", func() {
+		pwd, _ := os.Getwd()
+		versionFile := filepath.Join(filepath.Dir(pwd), ".php-version")
+
+		Execute("go", "run", path, "php@8.1.27")
+		Execute("go", "run", path, "php@8.2.15")
+
+		io.WriteFile(versionFile, "8.1.27")
+
+		command, _ := Command("go", "run", path, "ls", "php").Output()
+
+		Expect(strings.Contains(string(command), "♥ 8.1.27")).To(Equal(true))
+
+		err := os.RemoveAll(versionFile)
+
+		Expect(err).To(BeNil())
+
+		Execute("go", "run", path, "rm", "php@8.1.27")
+		Execute("go", "run", path, "rm", "php@8.2.15")
+	})
+})
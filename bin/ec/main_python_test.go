@@ -10,6 +10,7 @@ import (
 
 	"github.com/markelog/eclectica/io"
 	"github.com/markelog/eclectica/plugins"
+	"github.com/markelog/eclectica/variables"
 )
 
 var _ = Describe("python", func() {
@@ -275,4 +276,104 @@ var _ = Describe("python", func() {
 			Expect(strings.Contains(string(modules), "pytest")).To(Equal(true))
 		})
 	})
+	Describe("pypy", func() {
+		if shouldRun("python-pypy") == false {
+			return
+		}
+
+		It("should install pypy3.10-7.3.15 version", func() {
+			Execute("go", "run", path, "python@pypy3.10-7.3.15")
+			command, _ := Command("go", "run", path, "ls", "python").Output()
+
+			Expect(strings.Contains(string(command), "♥ pypy3.10-7.3.15")).To(Equal(true))
+		})
+
+		It("should execute pypy as python", func() {
+			version, _ := Command("python", "--version").CombinedOutput()
+
+			Expect(strings.Contains(string(version), "PyPy 7.3.15")).To(Equal(true))
+		})
+
+		It("should have pip installed", func() {
+			_, err := os.Stat(pipBin)
+
+			Expect(err).To(BeNil())
+		})
+
+		It("should use local version with the implementation prefix", func() {
+			pwd, _ := os.Getwd()
+			versionFile := filepath.Join(filepath.Dir(pwd), ".python-version")
+
+			io.WriteFile(versionFile, "pypy3.10-7.3.15")
+
+			command, _ := Command("go", "run", path, "ls", "python").Output()
+
+			Expect(strings.Contains(string(command), "♥ pypy3.10-7.3.15")).To(Equal(true))
+
+			os.RemoveAll(versionFile)
+
+			Execute("go", "run", path, "rm", "python@pypy3.10-7.3.15")
+		})
+	})
+	Describe("prebuilt", func() {
+		if shouldRun("python-prebuilt") == false {
+			return
+		}
+
+		It("should install prebuilt 3.12.1 version", func() {
+			Execute("go", "run", path, "python@3.12.1")
+			command, _ := Command("go", "run", path, "ls", "python").Output()
+
+			Expect(strings.Contains(string(command), "♥ 3.12.1")).To(Equal(true))
+		})
+
+		It("should execute it as python", func() {
+			version, _ := Command("python", "--version").CombinedOutput()
+
+			Expect(strings.Contains(string(version), "Python 3.12.1")).To(Equal(true))
+		})
+
+		It("should have pip installed", func() {
+			_, err := os.Stat(pipBin)
+
+			Expect(err).To(BeNil())
+
+			Execute("go", "run", path, "rm", "python@3.12.1")
+		})
+
+		It("should compile it if asked to", func() {
+			Execute("go", "run", path, "python@3.12.1", "--from-source")
+			command, _ := Command("go", "run", path, "ls", "python").Output()
+
+			Expect(strings.Contains(string(command), "♥ 3.12.1")).To(Equal(true))
+
+			Execute("go", "run", path, "rm", "python@3.12.1")
+		})
+	})
+	Describe("variants", func() {
+		if shouldRun("python-variants") == false {
+			return
+		}
+
+		It("should install free-threaded 3.13.1t version", func() {
+			Execute("go", "run", path, "python@3.13.1t")
+			command, _ := Command("go", "run", path, "ls", "python").Output()
+
+			Expect(strings.Contains(string(command), "♥ 3.13.1t")).To(Equal(true))
+		})
+
+		It("should have binary named with the variant", func() {
+			_, err := os.Stat(filepath.Join(variables.Path("python", "3.13.1t"), "bin", "python3.13t"))
+
+			Expect(err).To(BeNil())
+		})
+
+		It("should disable GIL", func() {
+			output, _ := Command("python", "-c", "import sys; print(sys._is_gil_enabled())").Output()
+
+			Expect(strings.TrimSpace(string(output))).To(Equal("False"))
+
+			Execute("go", "run", path, "rm", "python@3.13.1t")
+		})
+	})
 })
@@ -0,0 +1,59 @@
+package main_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/markelog/eclectica/io"
+)
+
+var _ = Describe("ruby implementations", func() {
+	if shouldRun("ruby-implementations") == false {
+		return
+	}
+
+	It("should install jruby-9.4.5.0 version", func() {
+		Execute("go", "run", path, "ruby@jruby-9.4.5.0")
+		command, _ := Command("go", "run", path, "ls", "ruby").Output()
+
+		Expect(strings.Contains(string(command), "♥ jruby-9.4.5.0")).To(Equal(true))
+	})
+
+	It("should execute jruby as ruby", func() {
+		version, _ := Command("ruby", "--version").CombinedOutput()
+
+		Expect(strings.Contains(string(version), "jruby 9.4.5.0")).To(Equal(true))
+	})
+
+	It("should use local version with the implementation prefix", func() {
+		pwd, _ := os.Getwd()
+		versionFile := filepath.Join(filepath.Dir(pwd), ".ruby-version")
+
+		io.WriteFile(versionFile, "jruby-9.4.5.0")
+
+		command, _ := Command("go", "run", path, "ls", "ruby").Output()
+
+		Expect(strings.Contains(string(command), "♥ jruby-9.4.5.0")).To(Equal(true))
+
+		os.RemoveAll(versionFile)
+
+		Execute("go", "run", path, "rm", "ruby@jruby-9.4.5.0")
+	})
+
+	It("should install truffleruby-23.1 version", func() {
+		Execute("go", "run", path, "ruby@truffleruby-23.1")
+		command, _ := Command("go", "run", path, "ls", "ruby").Output()
+
+		Expect(strings.Contains(string(command), "♥ truffleruby-23.1.")).To(Equal(true))
+	})
+
+	It("should execute truffleruby as ruby", func() {
+		version, _ := Command("ruby", "--version").CombinedOutput()
+
+		Expect(strings.Contains(string(version), "truffleruby 23.1.")).To(Equal(true))
+	})
+})
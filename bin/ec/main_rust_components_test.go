@@ -0,0 +1,37 @@
+package main_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/markelog/eclectica/variables"
+)
+
+var _ = Describe("rust components", func() {
+	if shouldRun("rust-components") == false {
+		return
+	}
+
+	version := "1.75.0"
+	rustlib := filepath.Join(variables.Path("rust", version), "lib", "rustlib")
+
+	It("should install rust with additional component and target", func() {
+		Execute(
+			"go", "run", path, "rust@"+version,
+			"--component", "rust-src", "--target", "wasm32-unknown-unknown",
+		)
+
+		_, err := os.Stat(filepath.Join(rustlib, "src", "rust"))
+		Expect(err).To(BeNil())
+	})
+
+	It("should have standard library for the target", func() {
+		_, err := os.Stat(filepath.Join(rustlib, "wasm32-unknown-unknown", "lib"))
+		Expect(err).To(BeNil())
+
+		Execute("go", "run", path, "rm", "rust@"+version)
+	})
+})
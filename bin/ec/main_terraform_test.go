@@ -0,0 +1,84 @@
+package main_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/markelog/eclectica/io"
+)
+
+var _ = Describe("terraform", func() {
+	if shouldRun("terraform") == false {
+		return
+	}
+
+	It("should install 1.6.6 version", func() {
+		Execute("go", "run", path, "terraform@1.6.6")
+
+		command, err := Command("go", "run", path, "ls", "terraform").CombinedOutput()
+
+		Expect(strings.Contains(string(command), "♥ 1.6.6")).To(Equal(true))
+		Expect(err).To(BeNil())
+
+		Execute("go", "run", path, "rm", "terraform@1.6.6")
+	})
+
+	It("should install one version after another", func() {
+		Execute("go", "run", path, "terraform@1.5.7")
+		Execute("go", "run", path, "terraform@1.6.6")
+
+		command, err := Command("go", "run", path, "ls", "terraform").CombinedOutput()
+
+		Expect(strings.Contains(string(command), "♥ 1.6.6")).To(Equal(true))
+		Expect(err).To(BeNil())
+
+		Execute("go", "run", path, "rm", "terraform@1.5.7")
+		Execute("go", "run", path, "rm", "terraform@1.6.6")
+	})
+
+	It("should use local version", func() {
+		pwd, _ := os.Getwd()
+		versionFile := filepath.Join(filepath.Dir(pwd), ".terraform-version")
+
+		Execute("go", "run", path, "terraform@1.5.7")
+		Execute("go", "run", path, "terraform@1.6.6")
+
+		io.WriteFile(versionFile, "1.5.7")
+
+		command, _ := Command("go", "run", path, "ls", "terraform").Output()
+
+		Expect(strings.Contains(string(command), "♥ 1.5.7")).To(Equal(true))
+
+		err := os.RemoveAll(versionFile)
+
+		Expect(err).To(BeNil())
+
+		Execute("go", "run", path, "rm", "terraform@1.5.7")
+		Execute("go", "run", path, "rm", "terraform@1.6.6")
+	})
+
+	It("should use version from \"required_version\" of the *.tf files", func() {
+		pwd, _ := os.Getwd()
+		versionFile := filepath.Join(filepath.Dir(pwd), "versions.tf")
+
+		Execute("go", "run", path, "terraform@1.5.7")
+		Execute("go", "run", path, "terraform@1.6.6")
+
+		io.WriteFile(versionFile, "terraform {\n  required_version = \"~> 1.5.0\"\n}\n")
+
+		command, _ := Command("go", "run", path, "ls", "terraform").Output()
+
+		Expect(strings.Contains(string(command), "♥ 1.5.7")).To(Equal(true))
+
+		err := os.RemoveAll(versionFile)
+
+		Expect(err).To(BeNil())
+
+		Execute("go", "run", path, "rm", "terraform@1.5.7")
+		Execute("go", "run", path, "rm", "terraform@1.6.6")
+	})
+})
@@ -0,0 +1,88 @@
+package main_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/variables"
+)
+
+var _ = Describe("zig", func() {
+	if shouldRun("zig") == false {
+		return
+	}
+
+	var (
+		index  = filepath.Join(variables.Cache(), "zig-index")
+		master = filepath.Join(variables.Cache(), "zig-master")
+	)
+
+	It("should install 0.11.0 version", func() {
+		Execute("go", "run", path, "zig@0.11.0")
+
+		command, err := Command("go", "run", path, "ls", "zig").CombinedOutput()
+
+		Expect(strings.Contains(string(command), "♥ 0.11.0")).To(Equal(true))
+		Expect(err).To(BeNil())
+
+		Execute("go", "run", path, "rm", "zig@0.11.0")
+	})
+
+	It("should install development build with \"master\" alias", func() {
+		Execute("go", "run", path, "zig@master")
+
+		version := strings.TrimSpace(io.Read(master))
+		Expect(version).NotTo(Equal(""))
+
+		command, _ := Command("go", "run", path, "ls", "zig").Output()
+
+		Expect(strings.Contains(string(command), "♥ "+version)).To(Equal(true))
+
+		Execute("go", "run", path, "rm", "zig@"+version)
+	})
+
+	It("should treat \"nightly\" as \"master\"", func() {
+		Execute("go", "run", path, "zig@nightly")
+
+		version := strings.TrimSpace(io.Read(master))
+
+		command, _ := Command("go", "run", path, "ls", "zig").Output()
+
+		Expect(strings.Contains(string(command), "♥ "+version)).To(Equal(true))
+
+		Execute("go", "run", path, "rm", "zig@"+version)
+	})
+
+	It("should not install archive with wrong checksum", func() {
+		defer os.Remove(index)
+
+		// Fill the index
+		Execute("go", "run", path, "zig@0.10.1")
+
+		builds := map[string]map[string]string{}
+		json.Unmarshal([]byte(io.Read(index)), &builds)
+
+		Expect(builds).To(HaveKey("0.11.0"))
+
+		builds["0.11.0"]["shasum"] = strings.Repeat("0", 64)
+		content, _ := json.Marshal(builds)
+		io.WriteFile(index, string(content))
+
+		err := Command("go", "run", path, "zig@0.11.0").Run()
+
+		Expect(err).NotTo(BeNil())
+
+		command, _ := Command("go", "run", path, "ls", "zig").Output()
+
+		Expect(strings.Contains(string(command), "♥ 0.10.1")).To(Equal(true))
+		Expect(strings.Contains(string(command), "0.11.0")).To(Equal(false))
+
+		Execute("go", "run", path, "rm", "zig@0.10.1")
+	})
+})
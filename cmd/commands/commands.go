@@ -5,10 +5,20 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+
+	"github.com/markelog/eclectica/cmd/print"
+	"github.com/markelog/eclectica/plugins"
+	"github.com/markelog/eclectica/variables"
 )
 
 var use = "ec [<language>@<version>]"
 
+// Should we never ask the user anything?
+var nonInteractive bool
+
+// Assume yes to the prompts, implies non-interactive mode
+var assumeYes bool
+
 // Command config
 var Command = &cobra.Command{
 	Use:     use,
@@ -28,7 +38,7 @@ func Execute() {
 	cmd, args, _ := Command.Find(args)
 	name := cmd.Name()
 
-	if name == "ec" && hasHelp(args) == false {
+	if name == "ec" && hasHelp(args) == false && isCompletion() == false {
 		augment()
 	}
 
@@ -39,7 +49,30 @@ func init() {
 	Command.SetHelpTemplate(help)
 	Command.SetUsageTemplate(usage)
 
-	cobra.OnInitialize()
+	flags := Command.PersistentFlags()
+	flags.BoolVar(&nonInteractive, "non-interactive", false, "never ask anything, fail with the possible choices instead")
+	flags.BoolVar(&assumeYes, "yes", false, "same as \"--non-interactive\", but also assume yes to the confirmations")
+
+	cobra.OnInitialize(setup)
+}
+
+// setup sets the mode of execution, non-interactive mode
+// is also enabled when stdin is not a terminal
+func setup() {
+	if nonInteractive || assumeYes {
+		os.Setenv("EC_NON_INTERACTIVE", "true")
+	}
+
+	if variables.IsInteractive() == false {
+		print.Plain()
+	}
+
+	// Upgrade of eclectica leaves old proxies behind, it's not critical
+	// if we can't update them here, so ignore the errors.
+	// Completion should be quick, it can wait for the next command
+	if isCompletion() == false && plugins.IsProxyOutdated() {
+		plugins.Rehash()
+	}
 }
 
 func augment() {
@@ -47,6 +80,18 @@ func augment() {
 	os.Args = append(os.Args[:1], append([]string{"install"}, os.Args[1:]...)...)
 }
 
+// isCompletion checks if shell asks for the completion,
+// cobra adds that command only on execution, so it can't be found beforehand
+func isCompletion() bool {
+	if len(os.Args) < 2 {
+		return false
+	}
+
+	name := os.Args[1]
+
+	return name == cobra.ShellCompRequestCmd || name == cobra.ShellCompNoDescRequestCmd
+}
+
 func hasHelp(args []string) bool {
 	for _, elem := range args {
 		if elem == `--help` || elem == `-h` {
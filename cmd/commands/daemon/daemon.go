@@ -0,0 +1,40 @@
+// Package daemon defines "daemon" command i.e. caches version resolution for the proxies
+package daemon
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/markelog/eclectica/cmd/print"
+	"github.com/markelog/eclectica/daemon"
+)
+
+// Command config
+var Command = &cobra.Command{
+	Use:   "daemon",
+	Short: "cache versions of the directories for the proxies",
+	Run:   run,
+}
+
+// Runner
+func run(c *cobra.Command, args []string) {
+	d, err := daemon.New()
+	print.Error(err)
+
+	err = d.Listen(daemon.Socket)
+	print.Error(err)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+
+	go func() {
+		<-signals
+		d.Close()
+	}()
+
+	err = d.Serve()
+	print.Error(err)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/schollz/closestmatch"
 	"github.com/spf13/cobra"
 
+	"github.com/markelog/eclectica/cmd/completion"
 	"github.com/markelog/eclectica/cmd/info"
 	"github.com/markelog/eclectica/cmd/print"
 	"github.com/markelog/eclectica/plugins"
@@ -22,11 +23,21 @@ var isLocal bool
 // Is action local?
 var withModules bool
 
+// Should language be compiled even if there is a prebuilt one?
+var fromSource bool
+
+// Additional parts of the toolchain, like "clippy"
+var components []string
+
+// Additional targets to compile for, like "wasm32-unknown-unknown"
+var targets []string
+
 // Command represents the ls command
 var Command = &cobra.Command{
-	Use:   "install [<language>@<version>]",
-	Short: "same as \"ec [<language>@<version>]\"",
-	Run:   run,
+	Use:               "install [<language>@<version>]",
+	Short:             "same as \"ec [<language>@<version>]\"",
+	Run:               run,
+	ValidArgsFunction: completion.Install,
 }
 
 // Event type handler
@@ -37,10 +48,13 @@ func getVersion(language, version string) string {
 	remoteList, err := info.FullListRemote(language)
 	print.Error(err)
 
+	// Variants are built from the same sources, so remote versions do not have them
+	version, variant := versions.SplitVariant(version)
+
 	version, err = versions.Complete(version, remoteList)
 	print.Error(err)
 
-	return version
+	return versions.JoinVariant(version, variant)
 }
 
 // Install either globally or locally
@@ -61,12 +75,33 @@ func conditionalInstall(plugin *plugins.Plugin) {
 	print.LastPrint()
 }
 
+// Stop using eclectica for the language either globally or locally
+func useSystem(language string) {
+	var (
+		err    error
+		plugin = plugins.New(&plugins.Args{
+			Language: language,
+		})
+	)
+
+	if isLocal {
+		err = plugin.LocalUseSystem()
+	} else {
+		err = plugin.UseSystem()
+	}
+
+	print.Error(err)
+}
+
 // Entry point for installation
 func install(language, version string) {
 	plugin := plugins.New(&plugins.Args{
 		Language:    language,
 		Version:     version,
 		WithModules: withModules,
+		FromSource:  fromSource,
+		Components:  components,
+		Targets:     targets,
 	})
 
 	err := plugin.PreDownload()
@@ -114,6 +149,23 @@ func run(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	// Plugin might list its versions differently, like "temurin-21" instead of "21"
+	if hasVersion {
+		version, err = plugins.New(&plugins.Args{
+			Language: language,
+		}).Normalize(version)
+		print.Error(err)
+	}
+
+	// In case of `ec <language>@system`
+	if version == plugins.System {
+		print.FnInStyleln("langauge:", language)
+		print.InStyleln(" version:", version)
+
+		useSystem(language)
+		return
+	}
+
 	// In case of `ec <language>@<partial-version like node@5>`
 	if hasVersion && versions.IsPartial(version) {
 		print.FnInStyleln("langauge:", language)
@@ -176,4 +228,7 @@ func init() {
 	flags.BoolVarP(&isRemote, "remote", "r", false, "get remote versions")
 	flags.BoolVarP(&isLocal, "local", "l", false, "install as local version")
 	flags.BoolVarP(&withModules, "with-modules", "w", false, "reinstall global modules from the previous version (currently works only for node.js)")
+	flags.BoolVarP(&fromSource, "from-source", "s", false, "compile the language even if there is a prebuilt one (currently works only for python)")
+	flags.StringSliceVarP(&components, "component", "c", nil, "install additional component of the toolchain (currently works only for rust)")
+	flags.StringSliceVarP(&targets, "target", "t", nil, "install standard library for additional target (currently works only for rust)")
 }
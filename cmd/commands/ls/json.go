@@ -0,0 +1,176 @@
+package ls
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/markelog/eclectica/cmd/print"
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/pkg"
+	"github.com/markelog/eclectica/plugins"
+	"github.com/markelog/eclectica/variables"
+	"github.com/markelog/eclectica/versions"
+)
+
+// localVersion is JSON representation of the installed version
+type localVersion struct {
+	Version string `json:"version"`
+	Current bool   `json:"current"`
+	DotFile string `json:"dotFile,omitempty"`
+	Path    string `json:"path"`
+	Size    int64  `json:"size"`
+}
+
+// remoteVersion is JSON representation of the remote version
+type remoteVersion struct {
+	Version    string `json:"version"`
+	Group      string `json:"group"`
+	Prerelease bool   `json:"prerelease"`
+	Date       string `json:"date,omitempty"`
+	LTS        string `json:"lts,omitempty"`
+}
+
+// jsonError is JSON representation of the language which versions couldn't be listed
+type jsonError struct {
+	Error string `json:"error"`
+}
+
+// Print versions of the provided languages or of all of them as JSON,
+// failure of one language doesn't prevent listing of the others
+func printJSON(args []string) {
+	var (
+		languages = plugins.Plugins
+		result    = map[string]interface{}{}
+	)
+
+	if len(args) > 0 {
+		languages = []string{args[0]}
+	}
+
+	for _, language := range languages {
+		var (
+			vers interface{}
+			err  error
+		)
+
+		if isRemote {
+			vers, err = jsonRemote(language)
+		} else {
+			vers, err = jsonLocal(language)
+		}
+
+		// There is nothing else to show if only this language was asked for
+		if err != nil && len(args) > 0 {
+			print.Error(err)
+		}
+
+		if err != nil {
+			vers = jsonError{Error: err.Error()}
+		}
+
+		result[language] = vers
+	}
+
+	output, err := json.MarshalIndent(result, "", "  ")
+	print.Error(err)
+
+	fmt.Println(string(output))
+}
+
+// Get all installed versions of the language
+func jsonLocal(language string) ([]localVersion, error) {
+	var (
+		result = []localVersion{}
+		plugin = plugins.New(&plugins.Args{
+			Language: language,
+		})
+		vers = plugin.List()
+	)
+
+	pwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	current, dotPath, err := plugin.LocalVersion(pwd)
+	if err != nil {
+		return nil, err
+	}
+
+	// In case we couldn't find `.<language>-version` file i.e. there is no local version
+	if current == "current" || current == "" {
+		current = plugin.Current()
+		dotPath = ""
+	}
+
+	completeCurrent, _ := versions.Complete(current, vers)
+
+	for _, version := range versions.Sort(vers) {
+		path := variables.Path(language, version)
+		size, _ := io.Size(path)
+		isCurrent := version == completeCurrent
+
+		entry := localVersion{
+			Version: version,
+			Current: isCurrent,
+			Path:    path,
+			Size:    size,
+		}
+
+		if isCurrent {
+			entry.DotFile = dotPath
+		}
+
+		result = append(result, entry)
+	}
+
+	return result, nil
+}
+
+// Get all remote versions of the language
+func jsonRemote(language string) ([]remoteVersion, error) {
+	var (
+		result   = []remoteVersion{}
+		releases = map[string]pkg.Release{}
+		plugin   = plugins.New(&plugins.Args{
+			Language: language,
+		})
+	)
+
+	vers, err := plugin.FullListRemote()
+	if err != nil {
+		return nil, err
+	}
+
+	// Not every plugin knows more than just the versions, and if it can't
+	// get the metadata now, versions are still useful without it
+	if releaser, ok := plugin.Pkg.(pkg.Releaser); ok {
+		list, _ := releaser.Releases()
+
+		for _, release := range list {
+			releases[release.Version] = release
+		}
+	}
+
+	groups := map[string]string{}
+	for group, elements := range versions.Compose(vers) {
+		for _, element := range elements {
+			groups[element] = group
+		}
+	}
+
+	for _, version := range versions.Sort(vers) {
+		release := releases[version]
+
+		result = append(result, remoteVersion{
+			Version:    version,
+			Group:      groups[version],
+			Prerelease: versions.IsPrerelease(version),
+			Date:       release.Date,
+			LTS:        release.LTS,
+		})
+	}
+
+	return result, nil
+}
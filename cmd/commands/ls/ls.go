@@ -3,14 +3,15 @@ package ls
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/go-errors/errors"
 	"github.com/schollz/closestmatch"
 	"github.com/spf13/cobra"
 
+	"github.com/markelog/eclectica/cmd/completion"
 	"github.com/markelog/eclectica/cmd/info"
 	"github.com/markelog/eclectica/cmd/print"
-	"github.com/markelog/eclectica/io"
 	"github.com/markelog/eclectica/list"
 	"github.com/markelog/eclectica/plugins"
 	"github.com/markelog/eclectica/versions"
@@ -19,13 +20,17 @@ import (
 // Is action remote?
 var isRemote bool
 
+// Should output be in JSON?
+var isJSON bool
+
 // Command represents the ls command
 var Command = &cobra.Command{
-	Use:     "ls",
-	Aliases: []string{"list"},
-	Short:   "list installed language versions",
-	Example: example,
-	Run:     run,
+	Use:               "ls",
+	Aliases:           []string{"list"},
+	Short:             "list installed language versions",
+	Example:           example,
+	Run:               run,
+	ValidArgsFunction: completion.Language,
 }
 
 // Command example
@@ -40,7 +45,10 @@ var example = `
   $ ec ls
 
   List remote versions
-  $ ec ls -r`
+  $ ec ls -r
+
+  List all local versions as JSON
+  $ ec ls --json`
 
 // Runner
 func run(cmd *cobra.Command, args []string) {
@@ -55,6 +63,11 @@ func run(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	if isJSON {
+		printJSON(args)
+		return
+	}
+
 	if isRemote {
 		remote(args)
 	} else {
@@ -98,8 +111,10 @@ func listLocalVersions(language string) {
 		print.Error(err)
 	}
 
-	current, _, err := io.GetVersion(plugin.Dots())
+	pwd, err := os.Getwd()
+	print.Error(err)
 
+	current, _, err := plugin.LocalVersion(pwd)
 	print.Error(err)
 
 	// In case we could find `.<language>-version` file i.e. there is no local version
@@ -114,7 +129,8 @@ func listLocalVersions(language string) {
 func listLocal() {
 	fmt.Println()
 
-	language := list.List("langauge:", plugins.Plugins, 0)
+	language, err := list.List("langauge:", plugins.Plugins, 0)
+	print.Error(err)
 
 	listLocalVersions(language)
 }
@@ -135,7 +151,8 @@ func listRemoteVersions(language string) {
 func listRemote() {
 	fmt.Println()
 
-	language := list.List("langauge:", plugins.Plugins, 0)
+	language, err := list.List("langauge:", plugins.Plugins, 0)
+	print.Error(err)
 
 	listRemoteVersions(language)
 }
@@ -177,4 +194,5 @@ func init() {
 	flags := Command.PersistentFlags()
 
 	flags.BoolVarP(&isRemote, "remote", "r", false, "Get remote versions")
+	flags.BoolVar(&isJSON, "json", false, "Output all versions as JSON")
 }
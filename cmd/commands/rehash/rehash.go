@@ -0,0 +1,22 @@
+// Package rehash defines "rehash" command i.e. recreates language proxies
+package rehash
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/markelog/eclectica/cmd/print"
+	"github.com/markelog/eclectica/plugins"
+)
+
+// Command config
+var Command = &cobra.Command{
+	Use:   "rehash",
+	Short: "update proxies of the installed languages",
+	Run:   run,
+}
+
+// Runner
+func run(c *cobra.Command, args []string) {
+	err := plugins.Rehash()
+	print.Error(err)
+}
@@ -33,9 +33,12 @@ func init() {
 }
 
 func run(c *cobra.Command, args []string) {
+	// Global "--yes" flag means the same thing
+	yes, _ := c.Flags().GetBool("yes")
 
-	if assumeYes == false {
-		response := list.List("Are you sure?", []string{"yes", "no"}, 0)
+	if assumeYes == false && yes == false {
+		response, err := list.List("Are you sure?", []string{"yes", "no"}, 0)
+		print.Error(err)
 
 		if response == "no" {
 			return
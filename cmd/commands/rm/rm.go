@@ -5,6 +5,7 @@ import (
 	"github.com/schollz/closestmatch"
 	"github.com/spf13/cobra"
 
+	"github.com/markelog/eclectica/cmd/completion"
 	"github.com/markelog/eclectica/cmd/info"
 	"github.com/markelog/eclectica/cmd/print"
 	"github.com/markelog/eclectica/plugins"
@@ -12,11 +13,12 @@ import (
 
 // Command config
 var Command = &cobra.Command{
-	Use:     "rm [<language>@<version>]",
-	Aliases: []string{"remove"},
-	Short:   "remove language version",
-	Example: example,
-	Run:     run,
+	Use:               "rm [<language>@<version>]",
+	Aliases:           []string{"remove"},
+	Short:             "remove language version",
+	Example:           example,
+	Run:               run,
+	ValidArgsFunction: completion.Remove,
 }
 
 // Command example
@@ -58,6 +60,12 @@ func run(cmd *cobra.Command, args []string) {
 
 	print.Error(err)
 
+	// Plugin might list its versions differently, like "temurin-21.0.2" instead of "21.0.2"
+	version, err = plugins.New(&plugins.Args{
+		Language: language,
+	}).NormalizeInstalled(version)
+	print.Error(err)
+
 	remove(language, version)
 }
 
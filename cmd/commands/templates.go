@@ -9,7 +9,10 @@ const example = `
   $ ec go
 
   Same way to choose, plus install available Rust versions
-  $ ec -r rust`
+  $ ec -r rust
+
+  Use node provided by the OS instead of eclectica one
+  $ ec node@system`
 
 // Help output
 const help = `
@@ -0,0 +1,107 @@
+// Package completion provides dynamic shell completion for the commands
+package completion
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/plugins"
+	"github.com/markelog/eclectica/variables"
+)
+
+// Install completes `<language>@<version>` with installed and cached remote versions
+func Install(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return complete(args, toComplete, true)
+}
+
+// Remove completes `<language>@<version>` with installed versions only
+func Remove(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return complete(args, toComplete, false)
+}
+
+// Language completes only the language names
+func Language(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return filter(toComplete, plugins.Plugins), cobra.ShellCompDirectiveNoFileComp
+}
+
+// complete languages or versions of the language depending on the input
+func complete(args []string, toComplete string, withRemote bool) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	// Language is not yet known, so offer the languages with "@" already attached
+	if strings.Contains(toComplete, "@") == false {
+		languages := []string{}
+
+		for _, language := range plugins.Plugins {
+			languages = append(languages, language+"@")
+		}
+
+		return filter(toComplete, languages), cobra.ShellCompDirectiveNoSpace |
+			cobra.ShellCompDirectiveNoFileComp
+	}
+
+	language := strings.Split(toComplete, "@")[0]
+	if isLanguage(language) == false {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	result := []string{}
+	for _, version := range Versions(language, withRemote) {
+		result = append(result, language+"@"+version)
+	}
+
+	return filter(toComplete, result), cobra.ShellCompDirectiveNoFileComp
+}
+
+// Versions returns installed and, if needed, cached remote versions of the language
+// without touching the network or constructing the plugin, since shell waits for it
+func Versions(language string, withRemote bool) (result []string) {
+	result = io.ListVersions(variables.Prefix(language))
+
+	if withRemote == false {
+		return
+	}
+
+	installed := map[string]bool{}
+	for _, version := range result {
+		installed[version] = true
+	}
+
+	for _, version := range plugins.CachedListRemote(language) {
+		if installed[version] {
+			continue
+		}
+
+		result = append(result, version)
+	}
+
+	return
+}
+
+func isLanguage(language string) bool {
+	for _, plugin := range plugins.Plugins {
+		if plugin == language {
+			return true
+		}
+	}
+
+	return false
+}
+
+func filter(prefix string, candidates []string) (result []string) {
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, prefix) {
+			result = append(result, candidate)
+		}
+	}
+
+	return
+}
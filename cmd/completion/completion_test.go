@@ -0,0 +1,98 @@
+package completion_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/markelog/monkey"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/markelog/eclectica/cmd/completion"
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/plugins"
+	"github.com/markelog/eclectica/variables"
+)
+
+var _ = Describe("completion", func() {
+	Describe("Install", func() {
+		It("should complete languages with the version separator", func() {
+			result, directive := completion.Install(nil, []string{}, "no")
+
+			Expect(result).To(Equal([]string{"node@"}))
+			Expect(directive & cobra.ShellCompDirectiveNoSpace).NotTo(BeZero())
+		})
+
+		It("should offer all the languages for the empty input", func() {
+			result, _ := completion.Install(nil, []string{}, "")
+
+			Expect(result).To(HaveLen(len(plugins.Plugins)))
+			Expect(result).To(ContainElement("rust@"))
+		})
+
+		It("should not complete unknown languages", func() {
+			result, _ := completion.Install(nil, []string{}, "nope@")
+
+			Expect(result).To(BeEmpty())
+		})
+
+		It("should not complete second argument", func() {
+			result, _ := completion.Install(nil, []string{"node@6.4.0"}, "")
+
+			Expect(result).To(BeEmpty())
+		})
+	})
+
+	Describe("Remove", func() {
+		It("should complete languages with the version separator", func() {
+			result, _ := completion.Remove(nil, []string{}, "ru")
+
+			Expect(result).To(ConsistOf("rust@", "ruby@"))
+		})
+	})
+
+	Describe("Language", func() {
+		It("should complete only the language name", func() {
+			result, directive := completion.Language(nil, []string{}, "p")
+
+			Expect(result).To(ConsistOf("python", "php"))
+			Expect(directive).To(Equal(cobra.ShellCompDirectiveNoFileComp))
+		})
+	})
+
+	Describe("Versions", func() {
+		var tmp string
+
+		BeforeEach(func() {
+			tmp, _ = ioutil.TempDir("", "eclectica-completion")
+
+			monkey.Patch(variables.Home, func() string {
+				return filepath.Join(tmp, "versions")
+			})
+
+			monkey.Patch(variables.Cache, func() string {
+				return filepath.Join(tmp, "cache")
+			})
+
+			os.MkdirAll(filepath.Join(tmp, "versions", "node", "6.4.0"), 0755)
+			io.CreateDir(filepath.Join(tmp, "cache"))
+			io.WriteFile(filepath.Join(tmp, "cache", "node-remote"), "7.0.0\n6.4.0")
+		})
+
+		AfterEach(func() {
+			monkey.Unpatch(variables.Home)
+			monkey.Unpatch(variables.Cache)
+			os.RemoveAll(tmp)
+		})
+
+		It("should list installed versions", func() {
+			Expect(completion.Versions("node", false)).To(Equal([]string{"6.4.0"}))
+		})
+
+		It("should add cached remote versions which are not installed", func() {
+			Expect(completion.Versions("node", true)).To(Equal([]string{"6.4.0", "7.0.0"}))
+		})
+	})
+})
@@ -7,7 +7,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/markelog/curse"
 	"github.com/markelog/eclectica/cmd/print"
 	"github.com/markelog/eclectica/cmd/print/spinner"
 	"github.com/markelog/eclectica/list"
@@ -19,7 +18,11 @@ import (
 func Ask() (language, version string, err error) {
 	fmt.Println()
 
-	language = list.List("langauge:", plugins.Plugins, 0)
+	language, err = list.List("langauge:", plugins.Plugins, 0)
+	if err != nil {
+		return
+	}
+
 	version, err = AskVersion(language)
 
 	return
@@ -36,16 +39,18 @@ func AskVersion(language string) (version string, err error) {
 		return
 	}
 
-	version = list.List("version:", vers, 1)
-
-	return
+	return list.List("version:", vers, 1)
 }
 
 // AskRemote asks for remote version from the user
 func AskRemote() (language, version string, err error) {
 	fmt.Println()
 
-	language = list.List("langauge:", plugins.Plugins, 0)
+	language, err = list.List("langauge:", plugins.Plugins, 0)
+	if err != nil {
+		return
+	}
+
 	version, err = AskRemoteVersion(language)
 
 	return
@@ -58,7 +63,11 @@ func AskRemoteVersions(language string) (vers []string, err error) {
 		return
 	}
 
-	key := list.List("mask:", versions.GetKeys(remoteList), 4)
+	key, err := list.List("mask:", versions.GetKeys(remoteList), 4)
+	if err != nil {
+		return
+	}
+
 	vers = versions.GetElements(key, remoteList)
 
 	return
@@ -72,9 +81,7 @@ func AskRemoteVersion(language string) (version string, err error) {
 		return
 	}
 
-	version = list.List("version:", versions, 1)
-
-	return
+	return list.List("version:", versions, 1)
 }
 
 // PossibleLanguage gets the most possible language that user probably meant
@@ -113,7 +120,7 @@ func GetLanguage(args []string) (language, version string) {
 
 // GetSpinner gets the spinner, just easier that way
 func GetSpinner(language string, prefix spinner.Fn) *spinner.Spinner {
-	c, _ := curse.New()
+	c := print.Cursor()
 
 	before := func() {}
 
@@ -123,8 +130,7 @@ func GetSpinner(language string, prefix spinner.Fn) *spinner.Spinner {
 	}
 
 	after := func() {
-		c.MoveUp(1)
-		c.EraseCurrentLine()
+		print.EraseLine(c)
 		print.InStyleln("langauge:", language)
 	}
 
@@ -136,10 +142,9 @@ func ListRemote(language string) (versions map[string][]string, err error) {
 	plugin := plugins.New(&plugins.Args{
 		Language: language,
 	})
-	c, _ := curse.New()
+	c := print.Cursor()
 	s := GetSpinner(language, func() {
-		c.MoveUp(1)
-		c.EraseCurrentLine()
+		print.EraseLine(c)
 		print.InStyle("langauge:", language)
 	})
 
@@ -155,15 +160,14 @@ func FullListRemote(language string) (versions []string, err error) {
 	plugin := plugins.New(&plugins.Args{
 		Language: language,
 	})
-	c, _ := curse.New()
+	c := print.Cursor()
 	s := GetSpinner(language, func() {
-		c.MoveUp(1)
-		c.EraseCurrentLine()
+		print.EraseLine(c)
 		print.InStyle("langauge:", language)
 	})
 
 	s.Start()
-	versions, err = plugin.Pkg.ListRemote()
+	versions, err = plugin.FullListRemote()
 	s.Stop()
 
 	return
@@ -6,8 +6,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/markelog/curse"
-
 	"github.com/markelog/eclectica/cmd/print"
 	"github.com/markelog/eclectica/cmd/print/spinner"
 )
@@ -80,7 +78,7 @@ func (me Spin) Stop() {
 }
 
 func (me *Spin) constructSpinner() {
-	cursed, _ := curse.New()
+	cursed := print.Cursor()
 
 	before := func() {}
 
@@ -89,10 +87,12 @@ func (me *Spin) constructSpinner() {
 		me.mutex.Lock()
 		defer me.mutex.Unlock()
 
-		cursed.MoveUp(1)
+		if cursed != nil {
+			cursed.MoveUp(1)
 
-		if started {
-			cursed.EraseCurrentLine()
+			if started {
+				cursed.EraseCurrentLine()
+			}
 		}
 		started = true
 
@@ -119,8 +119,7 @@ func (me *Spin) constructSpinner() {
 		me.mutex.Lock()
 		defer me.mutex.Unlock()
 
-		cursed.MoveUp(1)
-		cursed.EraseCurrentLine()
+		print.EraseLine(cursed)
 		print.InStyleln(me.Header, me.Item)
 	}
 
@@ -21,7 +21,6 @@ import (
 )
 
 var (
-
 	// Gray color
 	Gray = ansi.ColorCode("240")
 	// White color
@@ -32,6 +31,46 @@ var (
 	Timeout = 200 * time.Millisecond
 )
 
+func init() {
+	if variables.IsInteractive() == false {
+		Plain()
+	}
+}
+
+// Plain disables colors, nobody would see them in non-interactive mode anyway
+func Plain() {
+	ansi.DisableColors(true)
+
+	Gray = ""
+	White = ""
+	Reset = ""
+}
+
+// Cursor returns terminal cursor or nil if there is no terminal to move it in,
+// in non-interactive mode the terminal is not even queried
+func Cursor() *curse.Cursor {
+	if variables.IsInteractive() == false {
+		return nil
+	}
+
+	cursed, err := curse.New()
+	if err != nil {
+		return nil
+	}
+
+	return cursed
+}
+
+// EraseLine moves cursor to the previous line and erases it
+func EraseLine(cursed *curse.Cursor) {
+	if cursed == nil {
+		return
+	}
+
+	cursed.MoveUp(1)
+	cursed.EraseCurrentLine()
+}
+
 // InStyle prints header and text with style
 func InStyle(name, entity string) {
 	name = ansi.Color(name, "white+b")
@@ -109,7 +148,7 @@ func Error(err error) {
 func Download(response *grab.Response, version string) string {
 	Error(response.Error)
 
-	cursed, _ := curse.New()
+	cursed := Cursor()
 
 	sizeAndTransfer := func() (size, transfer string) {
 		size = humanize.Bytes(response.Size)
@@ -123,8 +162,7 @@ func Download(response *grab.Response, version string) string {
 	before := func() {}
 
 	prefix := func() {
-		cursed.MoveUp(1)
-		cursed.EraseCurrentLine()
+		EraseLine(cursed)
 
 		size, transfer := sizeAndTransfer()
 		text := fmt.Sprintf("(%s/%s ", transfer, size)
@@ -145,8 +183,7 @@ func Download(response *grab.Response, version string) string {
 	after := func() {
 		Error(response.Error)
 
-		cursed.MoveUp(1)
-		cursed.EraseCurrentLine()
+		EraseLine(cursed)
 		InStyleln(" version:", version)
 	}
 
@@ -161,6 +198,9 @@ func Download(response *grab.Response, version string) string {
 	mutex.Unlock()
 	spin.Stop()
 
+	// Spinner might not be shown, so check it here as well
+	Error(response.Error)
+
 	return response.Filename
 }
 
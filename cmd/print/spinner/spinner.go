@@ -8,6 +8,8 @@ import (
 
 	"github.com/mgutz/ansi"
 	spin "github.com/tj/go-spin"
+
+	"github.com/markelog/eclectica/variables"
 )
 
 // Spinner essential struct
@@ -43,7 +45,7 @@ func (spinner *Spinner) Start() {
 	spinner.mutex.Lock()
 	defer spinner.mutex.Unlock()
 
-	if os.Getenv("EC_WITHOUT_SPINNER") == "true" {
+	if os.Getenv("EC_WITHOUT_SPINNER") == "true" || variables.IsInteractive() == false {
 		spinner.isDone = true
 		return
 	}
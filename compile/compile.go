@@ -0,0 +1,175 @@
+// Package compile provides configure/make/install pipeline
+// for the languages which are compiled from the source
+package compile
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/chuckpreslar/emission"
+	"github.com/go-errors/errors"
+	"github.com/kr/pty"
+
+	eStrings "github.com/markelog/eclectica/strings"
+)
+
+// Compile essential struct
+type Compile struct {
+	Dir     string
+	Env     []string
+	Emitter *emission.Emitter
+}
+
+// New returns compile struct for the source folder
+func New(dir string, emitter *emission.Emitter) *Compile {
+	return &Compile{
+		Dir:     dir,
+		Emitter: emitter,
+	}
+}
+
+// Configure executes "configure" script of the source with provided arguments
+func (compile *Compile) Configure(args ...string) error {
+	configure := filepath.Join(compile.Dir, "configure")
+
+	return compile.Run("configure", append([]string{configure}, args...)...)
+}
+
+// Prepare builds the source
+func (compile *Compile) Prepare() error {
+	return compile.Run("prepare", "make", "-j")
+}
+
+// Install installs the built source
+func (compile *Compile) Install() error {
+	return compile.Run("install", "make", "install", "-j")
+}
+
+// Run executes the command in the source folder,
+// every line of its output is emitted as an event
+func (compile *Compile) Run(event string, args ...string) (err error) {
+	var (
+		waitGroup = &sync.WaitGroup{}
+		stderr    = &strings.Builder{}
+	)
+
+	compile.Emitter.Emit(event)
+
+	cmd, errPipe, outPipe, tty, err := compile.getCmd(args...)
+	if err != nil {
+		return
+	}
+
+	compile.listen(waitGroup, errPipe, func(line string) {
+		stderr.WriteString(line + "\n")
+	})
+
+	compile.listen(waitGroup, outPipe, func(line string) {
+		compile.Emitter.Emit(event, eStrings.ElipsisForTerminal(line))
+	})
+
+	err = cmd.Start()
+
+	// Child has its own copy now, without closing ours output would never end
+	if tty != nil {
+		tty.Close()
+		defer outPipe.Close()
+	}
+
+	if err != nil {
+		return errors.New(err)
+	}
+
+	waitGroup.Wait()
+
+	err = cmd.Wait()
+	if err != nil {
+		return failure(args, stderr.String(), err)
+	}
+
+	return
+}
+
+func (compile *Compile) listen(waitGroup *sync.WaitGroup, pipe io.Reader, fn func(string)) {
+	scanner := bufio.NewScanner(pipe)
+
+	waitGroup.Add(1)
+	go func() {
+		defer waitGroup.Done()
+
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if len(line) == 0 {
+				continue
+			}
+
+			fn(line)
+		}
+	}()
+}
+
+func (compile *Compile) getCmd(args ...string) (
+	cmd *exec.Cmd,
+	stderr, stdout io.ReadCloser,
+	tty *os.File,
+	err error,
+) {
+	cmd = exec.Command(args[0], args[1:]...)
+
+	cmd.Env = append(os.Environ(), "LC_ALL=C") // Required for some reason
+	cmd.Env = append(cmd.Env, compile.Env...)
+	cmd.Dir = compile.Dir
+
+	stderr, err = cmd.StderrPipe()
+	if err != nil {
+		err = errors.New(err)
+		return
+	}
+
+	// In order to preserve colors output -
+	// trick the command into thinking this is real tty.
+	// Works properly only with "configure" command
+	if path.Base(args[0]) == "configure" {
+		stdout, tty, err = pty.Open()
+		if err != nil {
+			err = errors.New(err)
+			return
+		}
+
+		cmd.Stdout = tty
+		cmd.Stdin = tty
+
+		return
+	}
+
+	stdout, err = cmd.StdoutPipe()
+	if err != nil {
+		err = errors.New(err)
+		return
+	}
+
+	return
+}
+
+// failure makes an error out of the last lines of the stderr
+func failure(args []string, stderr string, err error) error {
+	lines := strings.Split(strings.TrimSpace(stderr), "\n")
+
+	if len(lines) > 5 {
+		lines = lines[len(lines)-5:]
+	}
+
+	message := "\"" + strings.Join(args, " ") + "\" failed with " + err.Error()
+
+	if strings.TrimSpace(stderr) != "" {
+		message += ":\n" + strings.Join(lines, "\n")
+	}
+
+	return errors.New(message)
+}
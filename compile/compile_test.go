@@ -0,0 +1,64 @@
+package compile_test
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/chuckpreslar/emission"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/markelog/eclectica/compile"
+)
+
+var _ = Describe("compile", func() {
+	var (
+		compile *Compile
+		events  map[string][]string
+	)
+
+	BeforeEach(func() {
+		dir, _ := filepath.Abs("./testdata/source")
+		emitter := emission.NewEmitter()
+		events = map[string][]string{}
+
+		for _, name := range []string{"configure", "prepare", "install"} {
+			event := name
+
+			emitter.On(event, func(args ...string) {
+				events[event] = append(events[event], strings.Join(args, ""))
+			})
+		}
+
+		compile = New(dir, emitter)
+	})
+
+	It("should emit output of the configure", func() {
+		err := compile.Configure("--prefix")
+
+		Expect(err).To(BeNil())
+		Expect(events["configure"]).To(Equal([]string{"", "checking for --prefix"}))
+	})
+
+	It("should emit output of the build", func() {
+		err := compile.Prepare()
+
+		Expect(err).To(BeNil())
+		Expect(events["prepare"]).To(Equal([]string{"", "building"}))
+	})
+
+	It("should pass the environment", func() {
+		compile.Env = []string{"PREFIX=/test"}
+
+		err := compile.Install()
+
+		Expect(err).To(BeNil())
+		Expect(events["install"]).To(Equal([]string{"", "installing /test"}))
+	})
+
+	It("should return stderr of the failed command", func() {
+		err := compile.Run("prepare", "make", "fail")
+
+		Expect(err.Error()).To(HavePrefix("\"make fail\" failed with exit status 2:\nbroken"))
+	})
+})
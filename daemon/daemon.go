@@ -0,0 +1,423 @@
+// Package daemon caches resolution of the language versions for the directories,
+// so proxies wouldn't need to walk up the filesystem tree on every start
+package daemon
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-errors/errors"
+	"gopkg.in/fsnotify/fsnotify.v1"
+
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/plugins"
+	"github.com/markelog/eclectica/variables"
+)
+
+var (
+	// Socket path on which daemon listens
+	Socket = filepath.Join(variables.Support(), "ec.sock")
+
+	// Timeout for the client to connect to the daemon,
+	// it should be small enough for proxy not to notice if daemon isn't there
+	Timeout = 50 * time.Millisecond
+
+	// Deadline for the whole request
+	Deadline = time.Second
+
+	// Capacity of the cache, least recently used entries are evicted after it,
+	// daemon lives long and proxies might be called in many different directories
+	Capacity = 1000
+)
+
+// Request of the client
+type Request struct {
+	Language string `json:"language"`
+	Dir      string `json:"dir"`
+}
+
+// Response of the daemon
+type Response struct {
+	Version string `json:"version"`
+	Path    string `json:"path"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Daemon essential struct
+type Daemon struct {
+	listener net.Listener
+	watcher  *fsnotify.Watcher
+	mutex    sync.Mutex
+	cache    map[Request]*entry
+	plugins  map[string]*plugins.Plugin
+	names    []string
+	watched  map[string]bool
+
+	// Number of cache entries and lookups in progress which need the directory
+	// to be watched, watcher is removed once nothing needs it
+	refs map[string]int
+
+	// Incremented on every flush of the cache, so lookups which were
+	// in progress during it wouldn't put outdated results in the cache
+	generation int
+
+	// Incremented on every use of the cache entry
+	tick int
+}
+
+// entry of the cache
+type entry struct {
+	response Response
+	used     int
+}
+
+// New returns new daemon
+func New() (*Daemon, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	daemon := &Daemon{
+		watcher: watcher,
+		cache:   map[Request]*entry{},
+		plugins: map[string]*plugins.Plugin{},
+		names:   []string{},
+		watched: map[string]bool{},
+		refs:    map[string]int{},
+	}
+
+	go daemon.watch()
+
+	return daemon, nil
+}
+
+// Listen on the socket, stale socket left by previous daemon is removed
+func (daemon *Daemon) Listen(socket string) (err error) {
+	_, err = io.CreateDir(filepath.Dir(socket))
+	if err != nil {
+		return
+	}
+
+	// Someone is already listening there
+	if conn, errDial := net.DialTimeout("unix", socket, Timeout); errDial == nil {
+		conn.Close()
+		return errors.New("Daemon is already running on \"" + socket + "\"")
+	}
+
+	os.Remove(socket)
+
+	daemon.listener, err = net.Listen("unix", socket)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	return
+}
+
+// Serve requests until daemon is closed
+func (daemon *Daemon) Serve() error {
+	for {
+		conn, err := daemon.listener.Accept()
+		if err != nil {
+			return nil
+		}
+
+		go daemon.handle(conn)
+	}
+}
+
+// Close stops the daemon and removes its socket
+func (daemon *Daemon) Close() (err error) {
+	daemon.watcher.Close()
+
+	if daemon.listener != nil {
+		err = daemon.listener.Close()
+	}
+
+	return
+}
+
+// Resolve version of the language for the directory
+func (daemon *Daemon) Resolve(request Request) Response {
+	daemon.mutex.Lock()
+	if cached, ok := daemon.cache[request]; ok {
+		daemon.tick++
+		cached.used = daemon.tick
+		daemon.mutex.Unlock()
+
+		return cached.response
+	}
+	daemon.mutex.Unlock()
+
+	response := Response{}
+
+	if isLanguage(request.Language) == false {
+		response.Error = "Unknown language \"" + request.Language + "\""
+		return response
+	}
+
+	plugin := daemon.getPlugin(request.Language)
+
+	// Watch before the lookup, so the changes made during it wouldn't be missed
+	generation := daemon.watchUp(request.Dir)
+
+	// Lookup walks the filesystem, so other requests shouldn't wait for it
+	version, path, err := plugin.LocalVersion(request.Dir)
+	if err != nil {
+		response.Error = err.Error()
+
+		// Do not cache errors, file might be in the middle of the write
+		daemon.mutex.Lock()
+		daemon.release(request.Dir)
+		daemon.mutex.Unlock()
+
+		return response
+	}
+
+	response.Version = version
+	response.Path = path
+
+	daemon.mutex.Lock()
+	daemon.store(request, response, generation)
+	daemon.mutex.Unlock()
+
+	return response
+}
+
+// Put the response in the cache, watchers of the lookup are passed to the entry,
+// unless result is outdated or was already put there by the concurrent request
+func (daemon *Daemon) store(request Request, response Response, generation int) {
+	_, exist := daemon.cache[request]
+	if exist || daemon.generation != generation {
+		daemon.release(request.Dir)
+		return
+	}
+
+	daemon.tick++
+	daemon.cache[request] = &entry{
+		response: response,
+		used:     daemon.tick,
+	}
+
+	if len(daemon.cache) > Capacity {
+		daemon.evict()
+	}
+}
+
+// Remove least recently used entry from the cache
+func (daemon *Daemon) evict() {
+	var (
+		oldest Request
+		used   = -1
+	)
+
+	for request, cached := range daemon.cache {
+		if used == -1 || cached.used < used {
+			oldest = request
+			used = cached.used
+		}
+	}
+
+	delete(daemon.cache, oldest)
+	daemon.release(oldest.Dir)
+}
+
+// Remove every entry from the cache
+func (daemon *Daemon) flush() {
+	for request := range daemon.cache {
+		daemon.release(request.Dir)
+	}
+
+	daemon.cache = map[Request]*entry{}
+	daemon.generation++
+}
+
+// Plugin is the same for every request of the language, so construct it only once
+func (daemon *Daemon) getPlugin(language string) *plugins.Plugin {
+	daemon.mutex.Lock()
+	plugin, ok := daemon.plugins[language]
+	daemon.mutex.Unlock()
+
+	if ok {
+		return plugin
+	}
+
+	plugin = plugins.New(&plugins.Args{
+		Language: language,
+	})
+
+	daemon.mutex.Lock()
+	defer daemon.mutex.Unlock()
+
+	// Might be constructed by the concurrent request
+	if existing, ok := daemon.plugins[language]; ok {
+		return existing
+	}
+
+	daemon.plugins[language] = plugin
+	daemon.names = append(daemon.names, plugin.Dots()...)
+
+	return plugin
+}
+
+// Watch every directory dot file might appear in,
+// returns generation of the cache these watchers are set for
+func (daemon *Daemon) watchUp(dir string) int {
+	daemon.mutex.Lock()
+	defer daemon.mutex.Unlock()
+
+	for {
+		daemon.refs[dir]++
+
+		if daemon.watched[dir] == false {
+			if daemon.watcher.Add(dir) == nil {
+				daemon.watched[dir] = true
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+
+		dir = parent
+	}
+
+	return daemon.generation
+}
+
+// Release watchers set by the watchUp for the same directory
+func (daemon *Daemon) release(dir string) {
+	for {
+		daemon.refs[dir]--
+
+		if daemon.refs[dir] <= 0 {
+			delete(daemon.refs, dir)
+
+			if daemon.watched[dir] {
+				daemon.watcher.Remove(dir)
+				delete(daemon.watched, dir)
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+
+		dir = parent
+	}
+}
+
+// Stop watching the directory and everything in it
+func (daemon *Daemon) unwatch(dir string) {
+	prefix := dir + string(filepath.Separator)
+
+	for path := range daemon.watched {
+		if path != dir && strings.HasPrefix(path, prefix) == false {
+			continue
+		}
+
+		// Removed directories lose their watchers by themselves
+		daemon.watcher.Remove(path)
+		delete(daemon.watched, path)
+	}
+}
+
+// Flush the cache whenever any dot file changes
+func (daemon *Daemon) watch() {
+	for {
+		select {
+		case event, ok := <-daemon.watcher.Events:
+			if ok == false {
+				return
+			}
+
+			daemon.mutex.Lock()
+
+			isDot := io.IsDot(daemon.names, filepath.Base(event.Name))
+
+			// Directories might be gone with their watchers
+			isGone := event.Op&(fsnotify.Remove|fsnotify.Rename) != 0 &&
+				daemon.watched[event.Name]
+
+			if isGone {
+				daemon.unwatch(event.Name)
+			}
+
+			if isDot || isGone {
+				daemon.flush()
+			}
+
+			daemon.mutex.Unlock()
+
+		case _, ok := <-daemon.watcher.Errors:
+			if ok == false {
+				return
+			}
+		}
+	}
+}
+
+func isLanguage(language string) bool {
+	for _, plugin := range plugins.Plugins {
+		if plugin == language {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (daemon *Daemon) handle(conn net.Conn) {
+	defer conn.Close()
+
+	conn.SetDeadline(time.Now().Add(Deadline))
+
+	request := Request{}
+	err := json.NewDecoder(conn).Decode(&request)
+	if err != nil {
+		return
+	}
+
+	json.NewEncoder(conn).Encode(daemon.Resolve(request))
+}
+
+// Resolve asks the daemon for the version of the language in the directory,
+// returns an error if daemon is not available or couldn't resolve it
+func Resolve(language, dir string) (version, path string, err error) {
+	conn, err := net.DialTimeout("unix", Socket, Timeout)
+	if err != nil {
+		return "", "", errors.New(err)
+	}
+
+	defer conn.Close()
+
+	conn.SetDeadline(time.Now().Add(Deadline))
+
+	err = json.NewEncoder(conn).Encode(Request{
+		Language: language,
+		Dir:      dir,
+	})
+	if err != nil {
+		return "", "", errors.New(err)
+	}
+
+	response := Response{}
+	err = json.NewDecoder(conn).Decode(&response)
+	if err != nil {
+		return "", "", errors.New(err)
+	}
+
+	if response.Error != "" {
+		return "", "", errors.New(response.Error)
+	}
+
+	return response.Version, response.Path, nil
+}
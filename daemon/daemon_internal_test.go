@@ -0,0 +1,106 @@
+package daemon
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/markelog/eclectica/io"
+)
+
+var _ = Describe("daemon cache", func() {
+	var (
+		old = Capacity
+		tmp string
+		d   *Daemon
+	)
+
+	resolve := func(name string) Response {
+		return d.Resolve(Request{
+			Language: "node",
+			Dir:      filepath.Join(tmp, name),
+		})
+	}
+
+	cached := func() (result []string) {
+		d.mutex.Lock()
+		defer d.mutex.Unlock()
+
+		for request := range d.cache {
+			result = append(result, filepath.Base(request.Dir))
+		}
+
+		return
+	}
+
+	watched := func(name string) bool {
+		d.mutex.Lock()
+		defer d.mutex.Unlock()
+
+		return d.watched[filepath.Join(tmp, name)]
+	}
+
+	BeforeEach(func() {
+		tmp, _ = ioutil.TempDir("", "eclectica-daemon")
+
+		for _, name := range []string{"a", "b", "c"} {
+			os.MkdirAll(filepath.Join(tmp, name), 0755)
+			io.WriteFile(filepath.Join(tmp, name, ".node-version"), "6.4.0")
+		}
+
+		d, _ = New()
+	})
+
+	AfterEach(func() {
+		Capacity = old
+
+		d.Close()
+		os.RemoveAll(tmp)
+	})
+
+	It("should evict least recently used entry", func() {
+		Capacity = 2
+
+		resolve("a")
+		resolve("b")
+		resolve("a")
+		resolve("c")
+
+		Expect(cached()).To(ConsistOf("a", "c"))
+	})
+
+	It("should stop watching directories of the evicted entries", func() {
+		Capacity = 1
+
+		resolve("a")
+
+		Expect(watched("a")).To(Equal(true))
+
+		resolve("b")
+
+		Expect(watched("a")).To(Equal(false))
+		Expect(watched("b")).To(Equal(true))
+		Expect(watched("")).To(Equal(true))
+	})
+
+	It("should stop watching directories after the flush", func() {
+		resolve("a")
+
+		io.WriteFile(filepath.Join(tmp, "a", ".node-version"), "7.0.0")
+
+		Eventually(func() bool { return watched("a") }).Should(Equal(false))
+		Expect(cached()).To(BeEmpty())
+		Expect(resolve("a").Version).To(Equal("7.0.0"))
+		Expect(watched("a")).To(Equal(true))
+	})
+
+	It("should not watch directories of failed lookups", func() {
+		io.WriteFile(filepath.Join(tmp, "a", ".node-version"), "not a version")
+
+		Expect(resolve("a").Error).NotTo(Equal(""))
+		Expect(watched("a")).To(Equal(false))
+	})
+})
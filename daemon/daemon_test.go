@@ -0,0 +1,127 @@
+package daemon_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/markelog/eclectica/daemon"
+	"github.com/markelog/eclectica/io"
+)
+
+var _ = Describe("daemon", func() {
+	var (
+		old = daemon.Socket
+		tmp string
+		dir string
+		d   *daemon.Daemon
+	)
+
+	version := func() string {
+		result, _, _ := daemon.Resolve("node", dir)
+		return result
+	}
+
+	BeforeEach(func() {
+		tmp, _ = ioutil.TempDir("", "eclectica-daemon")
+		dir = filepath.Join(tmp, "project", "nested")
+		daemon.Socket = filepath.Join(tmp, "ec.sock")
+
+		os.MkdirAll(dir, 0755)
+		io.WriteFile(filepath.Join(tmp, "project", ".node-version"), "6.4.0")
+	})
+
+	AfterEach(func() {
+		daemon.Socket = old
+		os.RemoveAll(tmp)
+	})
+
+	Describe("without daemon", func() {
+		It("should return an error", func() {
+			_, _, err := daemon.Resolve("node", dir)
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("with daemon", func() {
+		BeforeEach(func() {
+			d, _ = daemon.New()
+			d.Listen(daemon.Socket)
+
+			go d.Serve()
+		})
+
+		AfterEach(func() {
+			d.Close()
+		})
+
+		It("should resolve version of the directory", func() {
+			result, path, err := daemon.Resolve("node", dir)
+
+			Expect(err).To(BeNil())
+			Expect(result).To(Equal("6.4.0"))
+			Expect(path).To(Equal(filepath.Join(tmp, "project", ".node-version")))
+		})
+
+		It("should return \"current\" if there is no dot file", func() {
+			os.Remove(filepath.Join(tmp, "project", ".node-version"))
+
+			Expect(version()).To(Equal("current"))
+		})
+
+		It("should return an error for unknown language", func() {
+			_, _, err := daemon.Resolve("cobol", dir)
+
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should not start second daemon on the same socket", func() {
+			second, _ := daemon.New()
+			defer second.Close()
+
+			Expect(second.Listen(daemon.Socket)).To(HaveOccurred())
+		})
+
+		It("should notice changed dot file", func() {
+			Expect(version()).To(Equal("6.4.0"))
+
+			io.WriteFile(filepath.Join(tmp, "project", ".node-version"), "7.0.0")
+
+			Eventually(version).Should(Equal("7.0.0"))
+		})
+
+		It("should notice new dot file", func() {
+			Expect(version()).To(Equal("6.4.0"))
+
+			io.WriteFile(filepath.Join(dir, ".nvmrc"), "8.0.0")
+
+			Eventually(version).Should(Equal("8.0.0"))
+		})
+
+		It("should notice removed dot file", func() {
+			Expect(version()).To(Equal("6.4.0"))
+
+			os.Remove(filepath.Join(tmp, "project", ".node-version"))
+
+			Eventually(version).Should(Equal("current"))
+		})
+
+		It("should watch directory again after it was renamed", func() {
+			Expect(version()).To(Equal("6.4.0"))
+
+			os.Rename(filepath.Join(tmp, "project"), filepath.Join(tmp, "renamed"))
+			os.MkdirAll(dir, 0755)
+			io.WriteFile(filepath.Join(dir, ".nvmrc"), "8.0.0")
+
+			Eventually(version).Should(Equal("8.0.0"))
+
+			io.WriteFile(filepath.Join(dir, ".nvmrc"), "9.0.0")
+
+			Eventually(version).Should(Equal("9.0.0"))
+		})
+	})
+})
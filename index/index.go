@@ -0,0 +1,108 @@
+// Package index keeps track of the languages every proxy belongs to,
+// so ec-proxy could find the language without asking every plugin
+package index
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/go-errors/errors"
+
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/variables"
+)
+
+// Path to the index file, it doesn't live next to the proxies,
+// since everything in there is supposed to be a proxy
+func Path() string {
+	return filepath.Join(variables.Support(), "index")
+}
+
+// Read returns bin to language map, missing or broken index is just empty
+func Read() map[string]string {
+	result := map[string]string{}
+
+	content := io.Read(Path())
+	if content == "" {
+		return result
+	}
+
+	json.Unmarshal([]byte(content), &result)
+
+	return result
+}
+
+// Language returns language of the bin or an empty string if it's unknown
+func Language(bin string) string {
+	return Read()[bin]
+}
+
+// Add bins of the language to the index
+func Add(language string, bins []string) error {
+	return update(func(data map[string]string) {
+		for _, bin := range bins {
+			data[bin] = language
+		}
+	})
+}
+
+// Remove bins from the index
+func Remove(bins []string) error {
+	return update(func(data map[string]string) {
+		for _, bin := range bins {
+			delete(data, bin)
+		}
+	})
+}
+
+// update the index while holding the lock, otherwise two ec or proxy
+// processes might read the same index and one of them would lose its changes
+func update(fn func(map[string]string)) (err error) {
+	_, err = io.CreateDir(filepath.Dir(Path()))
+	if err != nil {
+		return
+	}
+
+	lock, err := os.OpenFile(Path()+".lock", os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	// Lock is released with the closed descriptor
+	defer lock.Close()
+
+	err = syscall.Flock(int(lock.Fd()), syscall.LOCK_EX)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	data := Read()
+
+	fn(data)
+
+	return write(data)
+}
+
+func write(data map[string]string) (err error) {
+	content, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return errors.New(err)
+	}
+
+	// Proxies might read it at the same time, so don't let them see half of it
+	tmp := Path() + ".tmp"
+
+	err = io.WriteFile(tmp, string(content))
+	if err != nil {
+		return
+	}
+
+	err = os.Rename(tmp, Path())
+	if err != nil {
+		return errors.New(err)
+	}
+
+	return
+}
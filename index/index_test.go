@@ -0,0 +1,90 @@
+package index_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/markelog/monkey"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/markelog/eclectica/index"
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/variables"
+)
+
+var _ = Describe("index", func() {
+	var tmp string
+
+	BeforeEach(func() {
+		tmp, _ = ioutil.TempDir("", "eclectica-index")
+
+		monkey.Patch(variables.Support, func() string {
+			return filepath.Join(tmp, "support")
+		})
+	})
+
+	AfterEach(func() {
+		monkey.Unpatch(variables.Support)
+		os.RemoveAll(tmp)
+	})
+
+	It("should keep index in the support folder", func() {
+		Expect(index.Path()).To(Equal(filepath.Join(tmp, "support", "index")))
+	})
+
+	It("should be empty if there is no index", func() {
+		Expect(index.Read()).To(BeEmpty())
+		Expect(index.Language("node")).To(Equal(""))
+	})
+
+	It("should be empty if index is broken", func() {
+		io.CreateDir(filepath.Dir(index.Path()))
+		io.WriteFile(index.Path(), "{")
+
+		Expect(index.Read()).To(BeEmpty())
+	})
+
+	It("should add bins of the language", func() {
+		err := index.Add("node", []string{"node", "npm"})
+
+		Expect(err).To(BeNil())
+		Expect(index.Language("npm")).To(Equal("node"))
+	})
+
+	It("should keep bins of other languages", func() {
+		index.Add("node", []string{"node", "npm"})
+		index.Add("go", []string{"go"})
+
+		Expect(index.Language("node")).To(Equal("node"))
+		Expect(index.Language("go")).To(Equal("go"))
+	})
+
+	It("should remove bins", func() {
+		index.Add("node", []string{"node", "npm"})
+		index.Remove([]string{"npm"})
+
+		Expect(index.Language("node")).To(Equal("node"))
+		Expect(index.Language("npm")).To(Equal(""))
+	})
+
+	It("should not lose bins added at the same time", func() {
+		var wg sync.WaitGroup
+
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+				index.Add("node", []string{fmt.Sprintf("bin%d", i)})
+			}(i)
+		}
+
+		wg.Wait()
+
+		Expect(index.Read()).To(HaveLen(20))
+	})
+})
@@ -4,10 +4,14 @@ package io
 
 import (
 	"bufio"
+	"encoding/hex"
+	"hash"
+	goio "io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 
 	"github.com/go-errors/errors"
 )
@@ -17,10 +21,14 @@ const (
 )
 
 var (
-	versionPattern = `(\d+(\.\d+)?(\.\d+)?)|(latest)`
+	versionPattern = `(\d+(\.\d+)?(\.\d+)?)|(latest)|(system)`
 	rVersion       = regexp.MustCompile(versionPattern)
 )
 
+// Parser extracts version from the dot file, empty version means
+// file doesn't define one and search should go on
+type Parser func(path string) (string, error)
+
 // Walker signature function
 type Walker func(path string) bool
 
@@ -107,6 +115,87 @@ func GetVersion(args ...interface{}) (version, path string, err error) {
 	return current, "", nil
 }
 
+// FindVersion walks up the filesystem tree from the folder and asks parser
+// for the version of every found dot file, "current" is returned if none defines it.
+// Dots might be patterns too, like "*.tf"
+func FindVersion(dots []string, dir string, parse Parser) (version, path string, err error) {
+	version = "current"
+
+	walkUp(dir, func(dir string) bool {
+		for _, file := range dots {
+			for _, dotPath := range findDots(dir, file) {
+				found, errParse := parse(dotPath)
+				if errParse != nil {
+					version, err = "", errParse
+					return true
+				}
+
+				if found != "" {
+					version, path = found, dotPath
+					return true
+				}
+			}
+		}
+
+		return false
+	})
+
+	return
+}
+
+// IsDot checks if file name matches any of the dots
+func IsDot(dots []string, name string) bool {
+	for _, dot := range dots {
+		if matched, _ := filepath.Match(dot, name); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// findDots gets paths of the existing files in the folder which match the dot
+func findDots(dir, dot string) []string {
+	dotPath := filepath.Join(dir, dot)
+
+	if strings.ContainsAny(dot, "*?[") {
+		matches, _ := filepath.Glob(dotPath)
+		return matches
+	}
+
+	if _, err := os.Stat(dotPath); err != nil {
+		return nil
+	}
+
+	return []string{dotPath}
+}
+
+// ReadVersion extracts version from the first line of the file
+func ReadVersion(path string) (string, error) {
+	content := strings.TrimSpace(Read(path))
+	if content == "" {
+		return "", nil
+	}
+
+	return ExtractVersion(strings.Split(content, "\n")[0])
+}
+
+// ReadToolVersion gets version of the tool from asdf-like ".tool-versions" file,
+// where every line is like "erlang 26.2.1", empty version means file doesn't define one
+func ReadToolVersion(path, tool string) string {
+	for _, line := range strings.Split(Read(path), "\n") {
+		line = strings.SplitN(line, "#", 2)[0]
+		fields := strings.Fields(line)
+
+		// There could be fallback versions, first one is preferred
+		if len(fields) > 1 && fields[0] == tool {
+			return fields[1]
+		}
+	}
+
+	return ""
+}
+
 // FindDotFile finds file up in the filesystem tree
 // by provided list of possible files
 func FindDotFile(args ...interface{}) (versionPath string, err error) {
@@ -211,3 +300,105 @@ func Symlink(current, base string) (err error) {
 
 	return nil
 }
+
+// Size returns size of all the files in the provided folder, links are not followed
+func Size(path string) (size int64, err error) {
+	err = filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, errors.New(err)
+	}
+
+	return
+}
+
+// Verify checksum of the file with the provided hasher
+func Verify(path, sum string, hasher hash.Hash) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	defer file.Close()
+
+	_, err = goio.Copy(hasher, file)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if hex.EncodeToString(hasher.Sum(nil)) != strings.ToLower(sum) {
+		return errors.New("Checksum mismatch for \"" + filepath.Base(path) + "\"")
+	}
+
+	return nil
+}
+
+// ListExecutables lists names of the executable files in the provided folder,
+// links are followed
+func ListExecutables(path string) (result []string) {
+	result = []string{}
+
+	files, _ := ioutil.ReadDir(path)
+	for _, file := range files {
+		name := file.Name()
+
+		// Links are resolved by os.Stat
+		info, err := os.Stat(filepath.Join(path, name))
+		if err != nil {
+			continue
+		}
+
+		if info.Mode().IsRegular() && info.Mode().Perm()&0111 != 0 {
+			result = append(result, name)
+		}
+	}
+
+	return
+}
+
+// LookPath searches for the executable in the PATH like the shell would,
+// but skips the folders inside of the excluded ones
+func LookPath(name string, exclude ...string) (string, error) {
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" || isInside(dir, exclude) {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+
+		if info.Mode().IsRegular() && info.Mode().Perm()&0111 != 0 {
+			return path, nil
+		}
+	}
+
+	return "", errors.New("\"" + name + "\" was not found in the PATH")
+}
+
+func isInside(path string, folders []string) bool {
+	path = filepath.Clean(path)
+
+	for _, folder := range folders {
+		folder = filepath.Clean(folder)
+
+		if path == folder || strings.HasPrefix(path, folder+string(filepath.Separator)) {
+			return true
+		}
+	}
+
+	return false
+}
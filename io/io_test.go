@@ -1,6 +1,9 @@
 package io_test
 
 import (
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -41,6 +44,13 @@ var _ = Describe("io", func() {
 			Expect(result).To(Equal("8.11.2"))
 		})
 
+		It("gets \"system\" keyword", func() {
+			result, err := ExtractVersion("system")
+
+			Expect(err).To(BeNil())
+			Expect(result).To(Equal("system"))
+		})
+
 		It("gets version with \"latest\" keyword", func() {
 			result, err := ExtractVersion("latest")
 
@@ -84,4 +94,229 @@ var _ = Describe("io", func() {
 			Expect(result).To(Equal("6.8.0"))
 		})
 	})
+
+	Describe("Size", func() {
+		It("should count size of all the files in the folder", func() {
+			path, _ := filepath.Abs("../testdata/io")
+
+			size, err := Size(path)
+
+			Expect(err).To(BeNil())
+			Expect(size).To(BeNumerically(">", 0))
+		})
+
+		It("should return an error for non-existent folder", func() {
+			_, err := Size("/does/not/exist")
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Verify", func() {
+		var (
+			tmp  string
+			path string
+		)
+
+		BeforeEach(func() {
+			tmp, _ = ioutil.TempDir("", "eclectica-verify")
+			path = filepath.Join(tmp, "archive")
+
+			WriteFile(path, "test")
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(tmp)
+		})
+
+		It("should accept matching checksum in any case", func() {
+			sum := "9F86D081884C7D659A2FEAA0C55AD015A3BF4F1B2B0B822CD15D6C15B0F00A08"
+
+			Expect(Verify(path, sum, sha256.New())).To(BeNil())
+		})
+
+		It("should reject mismatched checksum", func() {
+			err := Verify(path, strings.Repeat("0", 64), sha256.New())
+
+			Expect(err).To(MatchError(`Checksum mismatch for "archive"`))
+		})
+
+		It("should return an error for missing file", func() {
+			Expect(Verify(filepath.Join(tmp, "nope"), "", sha256.New())).NotTo(BeNil())
+		})
+	})
+
+	Describe("ListExecutables", func() {
+		var tmp string
+
+		BeforeEach(func() {
+			tmp, _ = ioutil.TempDir("", "eclectica-executables")
+
+			ioutil.WriteFile(filepath.Join(tmp, "node"), []byte(""), 0755)
+			ioutil.WriteFile(filepath.Join(tmp, "readme"), []byte(""), 0644)
+			os.Mkdir(filepath.Join(tmp, "folder"), 0755)
+			os.Symlink(filepath.Join(tmp, "node"), filepath.Join(tmp, "nodejs"))
+			os.Symlink(filepath.Join(tmp, "nope"), filepath.Join(tmp, "broken"))
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(tmp)
+		})
+
+		It("should list only executable files and links to them", func() {
+			Expect(ListExecutables(tmp)).To(ConsistOf("node", "nodejs"))
+		})
+
+		It("should return empty list for non-existent folder", func() {
+			Expect(ListExecutables("/does/not/exist")).To(BeEmpty())
+		})
+	})
+
+	Describe("LookPath", func() {
+		var (
+			tmp  string
+			path = os.Getenv("PATH")
+		)
+
+		BeforeEach(func() {
+			tmp, _ = ioutil.TempDir("", "eclectica-look-path")
+
+			os.MkdirAll(filepath.Join(tmp, "eclectica", "bin"), 0755)
+			os.MkdirAll(filepath.Join(tmp, "usr", "bin"), 0755)
+
+			ioutil.WriteFile(filepath.Join(tmp, "eclectica", "bin", "node"), []byte(""), 0755)
+			ioutil.WriteFile(filepath.Join(tmp, "usr", "bin", "node"), []byte(""), 0755)
+			ioutil.WriteFile(filepath.Join(tmp, "usr", "bin", "readme"), []byte(""), 0644)
+
+			os.Setenv("PATH", strings.Join([]string{
+				filepath.Join(tmp, "eclectica", "bin"),
+				filepath.Join(tmp, "usr", "bin"),
+			}, string(filepath.ListSeparator)))
+		})
+
+		AfterEach(func() {
+			os.Setenv("PATH", path)
+			os.RemoveAll(tmp)
+		})
+
+		It("should find first executable in the PATH", func() {
+			result, err := LookPath("node")
+
+			Expect(err).To(BeNil())
+			Expect(result).To(Equal(filepath.Join(tmp, "eclectica", "bin", "node")))
+		})
+
+		It("should skip excluded folders", func() {
+			result, err := LookPath("node", filepath.Join(tmp, "eclectica"))
+
+			Expect(err).To(BeNil())
+			Expect(result).To(Equal(filepath.Join(tmp, "usr", "bin", "node")))
+		})
+
+		It("should not find files which are not executable", func() {
+			_, err := LookPath("readme")
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("FindVersion", func() {
+		var (
+			tmp    string
+			nested string
+		)
+
+		parse := func(path string) (string, error) {
+			if filepath.Base(path) == "package.json" {
+				return "", nil
+			}
+
+			return ReadVersion(path)
+		}
+
+		BeforeEach(func() {
+			tmp, _ = ioutil.TempDir("", "eclectica-find-version")
+			nested = filepath.Join(tmp, "project", "nested")
+
+			os.MkdirAll(nested, 0755)
+			WriteFile(filepath.Join(nested, "package.json"), "{}")
+			WriteFile(filepath.Join(tmp, "project", ".bun-version"), "1.1.0")
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(tmp)
+		})
+
+		It("should skip files which do not define version", func() {
+			version, path, err := FindVersion([]string{".bun-version", "package.json"}, nested, parse)
+
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal("1.1.0"))
+			Expect(path).To(Equal(filepath.Join(tmp, "project", ".bun-version")))
+		})
+
+		It("should return \"current\" if nothing was found", func() {
+			version, path, err := FindVersion([]string{".nope-version"}, nested, parse)
+
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal("current"))
+			Expect(path).To(Equal(""))
+		})
+
+		It("should return parser error", func() {
+			WriteFile(filepath.Join(tmp, "project", ".bun-version"), "nope")
+
+			_, _, err := FindVersion([]string{".bun-version"}, nested, parse)
+
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should find files by pattern", func() {
+			WriteFile(filepath.Join(tmp, "project", "versions.tf"), "1.6.6")
+
+			version, path, err := FindVersion([]string{"*.tf"}, nested, parse)
+
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal("1.6.6"))
+			Expect(path).To(Equal(filepath.Join(tmp, "project", "versions.tf")))
+		})
+	})
+
+	Describe("IsDot", func() {
+		It("should match file names", func() {
+			Expect(IsDot([]string{".bun-version"}, ".bun-version")).To(Equal(true))
+			Expect(IsDot([]string{".bun-version"}, ".zig-version")).To(Equal(false))
+		})
+
+		It("should match patterns", func() {
+			Expect(IsDot([]string{".terraform-version", "*.tf"}, "main.tf")).To(Equal(true))
+			Expect(IsDot([]string{".terraform-version", "*.tf"}, "main.go")).To(Equal(false))
+		})
+	})
+
+	Describe("ReadToolVersion", func() {
+		var tmp string
+
+		BeforeEach(func() {
+			tmp, _ = ioutil.TempDir("", "eclectica-tool-versions")
+
+			WriteFile(filepath.Join(tmp, ".tool-versions"), `# comment
+erlang 26.2.1 25.3
+elixir   1.16.0-otp-26 # inline comment
+`)
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(tmp)
+		})
+
+		It("should get preferred version of the tool", func() {
+			Expect(ReadToolVersion(filepath.Join(tmp, ".tool-versions"), "erlang")).To(Equal("26.2.1"))
+			Expect(ReadToolVersion(filepath.Join(tmp, ".tool-versions"), "elixir")).To(Equal("1.16.0-otp-26"))
+		})
+
+		It("should return empty string for the missing tool", func() {
+			Expect(ReadToolVersion(filepath.Join(tmp, ".tool-versions"), "ruby")).To(Equal(""))
+		})
+	})
 })
@@ -1,15 +1,24 @@
 package list
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/go-errors/errors"
 	"github.com/markelog/list"
+
+	"github.com/markelog/eclectica/variables"
 )
 
 // List the plugins
-func List(header string, options []string, indent int) string {
+func List(header string, options []string, indent int) (string, error) {
 	strIndent := ""
 
+	// Nobody to ask, so tell what could've been chosen instead
+	if variables.IsInteractive() == false {
+		return "", notInteractive(header, options)
+	}
+
 	if indent > 0 {
 		strIndent = strings.Repeat(" ", indent)
 	}
@@ -24,5 +33,14 @@ func List(header string, options []string, indent int) string {
 	l.Show()
 
 	// Waiting for the user input
-	return l.Get()
+	return l.Get(), nil
+}
+
+func notInteractive(header string, options []string) error {
+	header = strings.TrimSuffix(strings.TrimSpace(header), ":")
+
+	return errors.New(fmt.Sprintf(
+		"Can't ask for \"%s\" in non-interactive mode, choose one of: %s",
+		header, strings.Join(options, ", "),
+	))
 }
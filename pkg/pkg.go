@@ -20,6 +20,63 @@ type Pkg interface {
 	Dots() []string
 }
 
+// Release describes remote version with the additional metadata
+type Release struct {
+	Version string
+	Date    string
+	LTS     string
+}
+
+// Releaser is implemented by plugins which can provide
+// metadata for the remote versions
+type Releaser interface {
+	Releases() ([]Release, error)
+}
+
+// BinFilter is implemented by plugins which need to exclude some of
+// the executables found in the bin folder of the installed version
+type BinFilter interface {
+	IsBin(path string) bool
+}
+
+// PackageFilter is implemented by plugins which can tell executables
+// installed by package managers from the ones shipped with the language
+type PackageFilter interface {
+	IsPackage(path string) bool
+}
+
+// VersionReader is implemented by plugins which can find the version
+// in the files of their own format, like "package.json".
+// Empty version means file doesn't define one
+type VersionReader interface {
+	ReadVersion(path string) (string, error)
+}
+
+// Normalizer is implemented by plugins which list their versions differently
+// from how user might provide them, like "temurin-21" instead of "21"
+type Normalizer interface {
+	Normalize(version string) (string, error)
+}
+
+// InstalledNormalizer is implemented by plugins which versions provided by the user
+// point to some other installed version, like "nightly" to the latest installed nightly
+type InstalledNormalizer interface {
+	NormalizeInstalled(version string, installed []string) string
+}
+
+// Extender is implemented by plugins which can add the parts requested
+// for the version even if it's already installed, like rust components
+type Extender interface {
+	Extend() error
+}
+
+// Chooser is implemented by plugins which can install the version in more
+// than one way and need the network to choose, like prebuilt or compiled python,
+// so it's done only before the download
+type Chooser interface {
+	Choose() (Pkg, error)
+}
+
 // Base struct from which every plugin should inherit
 type Base struct {
 	Version string
@@ -0,0 +1,196 @@
+// Package bun provides all needed logic for installation of Bun
+package bun
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/chuckpreslar/emission"
+	"github.com/go-errors/errors"
+
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/pkg"
+	"github.com/markelog/eclectica/request"
+	"github.com/markelog/eclectica/variables"
+)
+
+var (
+	// VersionLink is the URL link from which we can get all possible versions
+	VersionLink = "https://api.github.com/repos/oven-sh/bun/tags"
+
+	// DownloadLink from which we download binaries for bun
+	DownloadLink = "https://github.com/oven-sh/bun/releases/download"
+
+	// Versions before that one are not considered stable
+	minimalVersion, _ = semver.Make("1.0.0")
+
+	bins = []string{"bun", "bunx"}
+	dots = []string{".bun-version", "package.json"}
+
+	rVersion = regexp.MustCompile(`^bun-v(\d+\.\d+\.\d+)$`)
+
+	// Exact version of the "engines" field, ranges like "^1.1.0" can't be pinned
+	rEngine = regexp.MustCompile(`^(?:=|v)?(\d+\.\d+\.\d+)$`)
+)
+
+// Bun essential struct
+type Bun struct {
+	Version string
+	Emitter *emission.Emitter
+	pkg.Base
+}
+
+// packageJSON is the part of the package.json bun might be defined in
+type packageJSON struct {
+	PackageManager string            `json:"packageManager"`
+	Engines        map[string]string `json:"engines"`
+}
+
+// New returns language struct
+func New(version string, emitter *emission.Emitter) *Bun {
+	return &Bun{
+		Version: version,
+		Emitter: emitter,
+	}
+}
+
+// Events returns language related event emitter
+func (bun Bun) Events() *emission.Emitter {
+	return bun.Emitter
+}
+
+// Install hook
+func (bun Bun) Install() (err error) {
+	var (
+		path = variables.Path("bun", bun.Version)
+		bin  = filepath.Join(path, "bin")
+	)
+
+	// Already moved
+	if _, errStat := os.Stat(filepath.Join(bin, "bun")); errStat == nil {
+		return
+	}
+
+	_, err = io.CreateDir(bin)
+	if err != nil {
+		return
+	}
+
+	err = os.Rename(filepath.Join(path, "bun"), filepath.Join(bin, "bun"))
+	if err != nil {
+		return errors.New(err)
+	}
+
+	// That's how official installer does it
+	err = os.Symlink("bun", filepath.Join(bin, "bunx"))
+	if err != nil {
+		return errors.New(err)
+	}
+
+	return
+}
+
+// Environment returns list of the all needed envionment variables
+func (bun Bun) Environment() (result []string, err error) {
+	// So packages installed with "bun add -g" wouldn't collide between versions
+	result = append(result, "BUN_INSTALL="+variables.Path("bun", bun.Version))
+
+	return
+}
+
+// Info provides all the info needed for installation of the plugin
+func (bun Bun) Info() map[string]string {
+	result := make(map[string]string)
+
+	platform, _ := getPlatform()
+
+	result["filename"] = "bun-" + platform
+	result["extension"] = "zip"
+	result["url"] = fmt.Sprintf("%s/bun-v%s/%s.zip", DownloadLink, bun.Version, result["filename"])
+
+	return result
+}
+
+// Bins returns list of the all bins included
+// with the distribution of the language
+func (bun Bun) Bins() []string {
+	return bins
+}
+
+// Dots returns list of the all available filenames
+// which can define versions
+func (bun Bun) Dots() []string {
+	return dots
+}
+
+// ReadVersion gets version from the dot file or from
+// "packageManager" or "engines.bun" fields of the package.json,
+// the latter is used only if it has an exact version
+func (bun Bun) ReadVersion(path string) (string, error) {
+	if filepath.Base(path) != "package.json" {
+		return io.ReadVersion(path)
+	}
+
+	data := packageJSON{}
+
+	// package.json is not ours, so the broken one shouldn't stop the search
+	err := json.Unmarshal([]byte(io.Read(path)), &data)
+	if err != nil {
+		return "", nil
+	}
+
+	if strings.HasPrefix(data.PackageManager, "bun@") {
+		return io.ExtractVersion(data.PackageManager)
+	}
+
+	match := rEngine.FindStringSubmatch(strings.TrimSpace(data.Engines["bun"]))
+	if len(match) > 0 {
+		return match[1], nil
+	}
+
+	return "", nil
+}
+
+// ListRemote returns list of the all available remote versions
+func (bun Bun) ListRemote() (result []string, err error) {
+	tags, err := request.GitHubTags(VersionLink)
+	if err != nil {
+		return
+	}
+
+	result = []string{}
+	for _, tag := range tags {
+		match := rVersion.FindStringSubmatch(tag)
+		if len(match) == 0 {
+			continue
+		}
+
+		version, errParse := semver.Make(match[1])
+		if errParse != nil || version.LT(minimalVersion) {
+			continue
+		}
+
+		result = append(result, match[1])
+	}
+
+	return
+}
+
+func getPlatform() (string, error) {
+	arch := "x64"
+	if runtime.GOARCH == "arm64" {
+		arch = "aarch64"
+	}
+
+	if runtime.GOOS == "linux" || runtime.GOOS == "darwin" {
+		return runtime.GOOS + "-" + arch, nil
+	}
+
+	return "", errors.New("Not supported environment")
+}
@@ -0,0 +1,13 @@
+package bun_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestBun(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Bun Suite")
+}
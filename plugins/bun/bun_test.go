@@ -0,0 +1,180 @@
+package bun_test
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/markelog/monkey"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	eIO "github.com/markelog/eclectica/io"
+	. "github.com/markelog/eclectica/plugins/bun"
+	"github.com/markelog/eclectica/variables"
+)
+
+var _ = Describe("bun", func() {
+	var (
+		remotes []string
+		err     error
+	)
+
+	bun := &Bun{}
+
+	Describe("ListRemote", func() {
+		old := VersionLink
+
+		AfterEach(func() {
+			VersionLink = old
+		})
+
+		BeforeEach(func() {
+			content := eIO.Read("./testdata/tags.json")
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				io.WriteString(w, content)
+			}))
+
+			VersionLink = ts.URL
+
+			remotes, err = bun.ListRemote()
+		})
+
+		It("should not return an error", func() {
+			Expect(err).To(BeNil())
+		})
+
+		It("should have only stable versions", func() {
+			Expect(remotes).To(Equal([]string{"1.1.3", "1.1.0", "1.0.0"}))
+		})
+	})
+
+	Describe("Info", func() {
+		It("should get info about 1.1.3 version", func() {
+			result := (&Bun{Version: "1.1.3"}).Info()
+
+			Expect(result["extension"]).To(Equal("zip"))
+
+			if runtime.GOOS == "linux" && runtime.GOARCH == "amd64" {
+				Expect(result["filename"]).To(Equal("bun-linux-x64"))
+				Expect(result["url"]).To(Equal("https://github.com/oven-sh/bun/releases/download/bun-v1.1.3/bun-linux-x64.zip"))
+			}
+
+			if runtime.GOOS == "darwin" && runtime.GOARCH == "arm64" {
+				Expect(result["filename"]).To(Equal("bun-darwin-aarch64"))
+				Expect(result["url"]).To(Equal("https://github.com/oven-sh/bun/releases/download/bun-v1.1.3/bun-darwin-aarch64.zip"))
+			}
+		})
+	})
+
+	Describe("Environment", func() {
+		It("should set global install folder for the version", func() {
+			result, err := (&Bun{Version: "1.1.3"}).Environment()
+
+			Expect(err).To(BeNil())
+			Expect(result).To(Equal([]string{
+				"BUN_INSTALL=" + variables.Path("bun", "1.1.3"),
+			}))
+		})
+	})
+
+	Describe("ReadVersion", func() {
+		It("should read version from the dot file", func() {
+			tmp, _ := ioutil.TempDir("", "eclectica-bun")
+			defer os.RemoveAll(tmp)
+
+			path := filepath.Join(tmp, ".bun-version")
+			eIO.WriteFile(path, "1.1.0\n")
+
+			Expect(bun.ReadVersion(path)).To(Equal("1.1.0"))
+		})
+
+		It("should read \"packageManager\" field", func() {
+			Expect(bun.ReadVersion("./testdata/package-manager/package.json")).To(Equal("1.1.3"))
+		})
+
+		It("should read \"engines.bun\" field", func() {
+			Expect(bun.ReadVersion("./testdata/engines/package.json")).To(Equal("1.1.0"))
+		})
+
+		It("should ignore range of the \"engines.bun\" field", func() {
+			Expect(bun.ReadVersion("./testdata/range/package.json")).To(Equal(""))
+		})
+
+		It("should skip package.json without bun", func() {
+			Expect(bun.ReadVersion("./testdata/none/package.json")).To(Equal(""))
+		})
+
+		It("should skip malformed package.json", func() {
+			tmp, _ := ioutil.TempDir("", "eclectica-bun")
+			defer os.RemoveAll(tmp)
+
+			path := filepath.Join(tmp, "package.json")
+			eIO.WriteFile(path, "{")
+
+			version, err := bun.ReadVersion(path)
+
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal(""))
+		})
+
+		It("should continue search after malformed package.json", func() {
+			tmp, _ := ioutil.TempDir("", "eclectica-bun")
+			defer os.RemoveAll(tmp)
+
+			dir := filepath.Join(tmp, "project")
+			os.MkdirAll(dir, 0755)
+			eIO.WriteFile(filepath.Join(dir, "package.json"), "{")
+			eIO.WriteFile(filepath.Join(tmp, ".bun-version"), "1.1.0")
+
+			version, path, err := eIO.FindVersion(bun.Dots(), dir, bun.ReadVersion)
+
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal("1.1.0"))
+			Expect(path).To(Equal(filepath.Join(tmp, ".bun-version")))
+		})
+
+		It("should find version up in the tree", func() {
+			dir, _ := filepath.Abs("./testdata/engines")
+			version, path, err := eIO.FindVersion(bun.Dots(), dir, bun.ReadVersion)
+
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal("1.1.0"))
+			Expect(path).To(Equal(filepath.Join(dir, "package.json")))
+		})
+	})
+
+	Describe("Install", func() {
+		var tmp string
+
+		BeforeEach(func() {
+			tmp, _ = ioutil.TempDir("", "eclectica-bun")
+
+			monkey.Patch(variables.Home, func() string {
+				return tmp
+			})
+
+			os.MkdirAll(filepath.Join(tmp, "bun", "1.1.3"), 0755)
+			ioutil.WriteFile(filepath.Join(tmp, "bun", "1.1.3", "bun"), []byte("binary"), 0755)
+		})
+
+		AfterEach(func() {
+			monkey.Unpatch(variables.Home)
+			os.RemoveAll(tmp)
+		})
+
+		It("should move binary to the bin folder along with bunx", func() {
+			err := (&Bun{Version: "1.1.3"}).Install()
+			bin := filepath.Join(tmp, "bun", "1.1.3", "bin")
+
+			Expect(err).To(BeNil())
+			Expect(eIO.Read(filepath.Join(bin, "bun"))).To(Equal("binary"))
+			Expect(eIO.Read(filepath.Join(bin, "bunx"))).To(Equal("binary"))
+		})
+	})
+})
@@ -0,0 +1,180 @@
+// Package deno provides all needed logic for installation of Deno
+package deno
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+
+	"github.com/blang/semver"
+	"github.com/chuckpreslar/emission"
+	"github.com/go-errors/errors"
+
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/pkg"
+	"github.com/markelog/eclectica/request"
+	"github.com/markelog/eclectica/variables"
+)
+
+var (
+	// VersionLink is the URL link from which we can get all possible versions
+	VersionLink = "https://api.github.com/repos/denoland/deno/tags"
+
+	// DownloadLink from which we download binaries for deno
+	DownloadLink = "https://github.com/denoland/deno/releases/download"
+
+	// Zip archives for every platform are available only from this version
+	minimalVersion, _ = semver.Make("1.0.0")
+
+	bins = []string{"deno"}
+	dots = []string{".dvmrc", ".deno-version"}
+
+	rVersion = regexp.MustCompile(`^v(\d+\.\d+\.\d+)$`)
+
+	// Architectures as they are named in the archives
+	archs = map[string]string{
+		"amd64": "x86_64",
+		"arm64": "aarch64",
+	}
+)
+
+// Deno essential struct
+type Deno struct {
+	Version string
+	Emitter *emission.Emitter
+	pkg.Base
+}
+
+// New returns language struct
+func New(version string, emitter *emission.Emitter) *Deno {
+	return &Deno{
+		Version: version,
+		Emitter: emitter,
+	}
+}
+
+// Events returns language related event emitter
+func (deno Deno) Events() *emission.Emitter {
+	return deno.Emitter
+}
+
+// Install hook
+func (deno Deno) Install() (err error) {
+	var (
+		path = variables.Path("deno", deno.Version)
+		tmp  = path + "-binary"
+		bin  = filepath.Join(path, "bin")
+	)
+
+	// Archive contains only the binary, so it was extracted in place of the version folder
+	info, err := os.Stat(path)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if info.IsDir() {
+		return
+	}
+
+	err = os.Rename(path, tmp)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	_, err = io.CreateDir(bin)
+	if err != nil {
+		return
+	}
+
+	err = os.Rename(tmp, filepath.Join(bin, "deno"))
+	if err != nil {
+		return errors.New(err)
+	}
+
+	return
+}
+
+// Environment returns list of the all needed envionment variables
+func (deno Deno) Environment() (result []string, err error) {
+	// So tools installed with "deno install" wouldn't collide between versions
+	result = append(result, "DENO_INSTALL_ROOT="+variables.Path("deno", deno.Version))
+
+	return
+}
+
+// PreDownload hook
+func (deno Deno) PreDownload() error {
+	_, err := getPlatform()
+
+	return err
+}
+
+// Info provides all the info needed for installation of the plugin
+func (deno Deno) Info() map[string]string {
+	result := make(map[string]string)
+
+	platform, _ := getPlatform()
+
+	result["filename"] = "deno-" + platform
+	result["unarchive-filename"] = "deno"
+	result["extension"] = "zip"
+	result["url"] = fmt.Sprintf("%s/v%s/%s.zip", DownloadLink, deno.Version, result["filename"])
+
+	return result
+}
+
+// Bins returns list of the all bins included
+// with the distribution of the language
+func (deno Deno) Bins() []string {
+	return bins
+}
+
+// Dots returns list of the all available filenames
+// which can define versions
+func (deno Deno) Dots() []string {
+	return dots
+}
+
+// ListRemote returns list of the all available remote versions
+func (deno Deno) ListRemote() (result []string, err error) {
+	tags, err := request.GitHubTags(VersionLink)
+	if err != nil {
+		return
+	}
+
+	result = []string{}
+	for _, tag := range tags {
+		match := rVersion.FindStringSubmatch(tag)
+		if len(match) == 0 {
+			continue
+		}
+
+		version, errParse := semver.Make(match[1])
+		if errParse != nil || version.LT(minimalVersion) {
+			continue
+		}
+
+		result = append(result, match[1])
+	}
+
+	return
+}
+
+func getPlatform() (string, error) {
+	arch, ok := archs[runtime.GOARCH]
+	if ok == false {
+		return "", errors.New("Not supported architecture \"" + runtime.GOARCH + "\"")
+	}
+
+	if runtime.GOOS == "linux" {
+		return arch + "-unknown-linux-gnu", nil
+	}
+
+	if runtime.GOOS == "darwin" {
+		return arch + "-apple-darwin", nil
+	}
+
+	return "", errors.New("Not supported environment")
+}
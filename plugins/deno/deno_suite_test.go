@@ -0,0 +1,13 @@
+package deno_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestDeno(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Deno Suite")
+}
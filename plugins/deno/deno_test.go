@@ -0,0 +1,139 @@
+package deno_test
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/markelog/monkey"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	eIO "github.com/markelog/eclectica/io"
+	. "github.com/markelog/eclectica/plugins/deno"
+	"github.com/markelog/eclectica/variables"
+)
+
+var _ = Describe("deno", func() {
+	var (
+		remotes []string
+		err     error
+	)
+
+	deno := &Deno{}
+
+	Describe("ListRemote", func() {
+		old := VersionLink
+
+		AfterEach(func() {
+			VersionLink = old
+		})
+
+		Describe("success", func() {
+			BeforeEach(func() {
+				content := eIO.Read("./testdata/tags.json")
+
+				ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					io.WriteString(w, content)
+				}))
+
+				VersionLink = ts.URL
+
+				remotes, err = deno.ListRemote()
+			})
+
+			It("should not return an error", func() {
+				Expect(err).To(BeNil())
+			})
+
+			It("should have only released versions", func() {
+				Expect(remotes).To(Equal([]string{"1.40.2", "1.40.1", "1.40.0", "1.0.0"}))
+			})
+		})
+
+		Describe("fail", func() {
+			BeforeEach(func() {
+				ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(500)
+				}))
+
+				VersionLink = ts.URL
+
+				remotes, err = deno.ListRemote()
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(MatchError(variables.ConnectionError))
+			})
+		})
+	})
+
+	Describe("Info", func() {
+		It("should get info about 1.40.0 version", func() {
+			result := (&Deno{Version: "1.40.0"}).Info()
+
+			Expect(result["extension"]).To(Equal("zip"))
+			Expect(result["unarchive-filename"]).To(Equal("deno"))
+
+			if runtime.GOOS == "linux" && runtime.GOARCH == "amd64" {
+				Expect(result["filename"]).To(Equal("deno-x86_64-unknown-linux-gnu"))
+				Expect(result["url"]).To(Equal("https://github.com/denoland/deno/releases/download/v1.40.0/deno-x86_64-unknown-linux-gnu.zip"))
+			}
+
+			if runtime.GOOS == "darwin" && runtime.GOARCH == "arm64" {
+				Expect(result["filename"]).To(Equal("deno-aarch64-apple-darwin"))
+				Expect(result["url"]).To(Equal("https://github.com/denoland/deno/releases/download/v1.40.0/deno-aarch64-apple-darwin.zip"))
+			}
+		})
+	})
+
+	Describe("Environment", func() {
+		It("should set install root for the version", func() {
+			result, err := (&Deno{Version: "1.40.0"}).Environment()
+
+			Expect(err).To(BeNil())
+			Expect(result).To(Equal([]string{
+				"DENO_INSTALL_ROOT=" + variables.Path("deno", "1.40.0"),
+			}))
+		})
+	})
+
+	Describe("Install", func() {
+		var tmp string
+
+		BeforeEach(func() {
+			tmp, _ = ioutil.TempDir("", "eclectica-deno")
+
+			monkey.Patch(variables.Home, func() string {
+				return tmp
+			})
+
+			os.MkdirAll(filepath.Join(tmp, "deno"), 0755)
+			ioutil.WriteFile(filepath.Join(tmp, "deno", "1.40.0"), []byte("binary"), 0755)
+		})
+
+		AfterEach(func() {
+			monkey.Unpatch(variables.Home)
+			os.RemoveAll(tmp)
+		})
+
+		It("should move extracted binary to the bin folder", func() {
+			err := (&Deno{Version: "1.40.0"}).Install()
+
+			Expect(err).To(BeNil())
+			Expect(eIO.Read(filepath.Join(tmp, "deno", "1.40.0", "bin", "deno"))).To(Equal("binary"))
+		})
+
+		It("should do nothing if binary is already in place", func() {
+			(&Deno{Version: "1.40.0"}).Install()
+			err := (&Deno{Version: "1.40.0"}).Install()
+
+			Expect(err).To(BeNil())
+			Expect(eIO.Read(filepath.Join(tmp, "deno", "1.40.0", "bin", "deno"))).To(Equal("binary"))
+		})
+	})
+})
@@ -0,0 +1,333 @@
+// Package dotnet provides all needed logic for installation of .NET SDK
+package dotnet
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/chuckpreslar/emission"
+	"github.com/go-errors/errors"
+
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/pkg"
+	"github.com/markelog/eclectica/request"
+	"github.com/markelog/eclectica/variables"
+	"github.com/markelog/eclectica/versions"
+)
+
+var (
+	// VersionLink is the URL of the releases index from which we get all possible versions
+	VersionLink = "https://dotnetcli.blob.core.windows.net/dotnet/release-metadata/releases-index.json"
+
+	// Channels before that one do not have archives for every platform
+	minimalChannel, _ = semver.Make("3.1.0")
+
+	bins = []string{"dotnet"}
+	dots = []string{".dotnet-version", "global.json"}
+)
+
+// Dotnet essential struct
+type Dotnet struct {
+	Version string
+	Emitter *emission.Emitter
+	pkg.Base
+}
+
+// channel is how index describes major.minor version of the .NET
+type channel struct {
+	Version  string `json:"channel-version"`
+	Releases string `json:"releases.json"`
+}
+
+// sdk is how releases.json describes the SDK
+type sdk struct {
+	Version string `json:"version"`
+	Files   []file `json:"files"`
+}
+
+// file is how releases.json describes the archive of the SDK
+type file struct {
+	Name string `json:"name"`
+	Rid  string `json:"rid"`
+	URL  string `json:"url"`
+	Hash string `json:"hash"`
+}
+
+// globalJSON is the part of the global.json SDK version is defined in
+type globalJSON struct {
+	SDK struct {
+		Version string `json:"version"`
+	} `json:"sdk"`
+}
+
+// New returns language struct
+func New(version string, emitter *emission.Emitter) *Dotnet {
+	return &Dotnet{
+		Version: version,
+		Emitter: emitter,
+	}
+}
+
+// Events returns language related event emitter
+func (dotnet Dotnet) Events() *emission.Emitter {
+	return dotnet.Emitter
+}
+
+// PreDownload hook
+func (dotnet Dotnet) PreDownload() error {
+	if _, ok := readFiles()[dotnet.Version]; ok {
+		return nil
+	}
+
+	// Archives are known only after we get the list
+	_, err := dotnet.ListRemote()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := readFiles()[dotnet.Version]; ok == false {
+		return errors.New("Incorrect version " + dotnet.Version)
+	}
+
+	return nil
+}
+
+// Install hook
+func (dotnet Dotnet) Install() (err error) {
+	var (
+		base = variables.Path("dotnet", dotnet.Version)
+		bin  = filepath.Join(base, "bin")
+	)
+
+	// Already linked
+	if _, errStat := os.Lstat(filepath.Join(bin, "dotnet")); errStat == nil {
+		return
+	}
+
+	_, err = io.CreateDir(bin)
+	if err != nil {
+		return
+	}
+
+	// Binary can't be moved, it looks for the SDK next to its real path
+	err = os.Symlink(filepath.Join("..", "dotnet"), filepath.Join(bin, "dotnet"))
+	if err != nil {
+		return errors.New(err)
+	}
+
+	return
+}
+
+// Environment returns list of the all needed envionment variables
+func (dotnet Dotnet) Environment() (result []string, err error) {
+	result = append(result, "DOTNET_ROOT="+variables.Path("dotnet", dotnet.Version))
+
+	return
+}
+
+// Info provides all the info needed for installation of the plugin
+func (dotnet Dotnet) Info() map[string]string {
+	var (
+		result = make(map[string]string)
+		item   = readFiles()[dotnet.Version]
+	)
+
+	result["filename"] = strings.TrimSuffix(path.Base(item.URL), ".tar.gz")
+	result["url"] = item.URL
+	result["sha512"] = item.Hash
+
+	// Archive has the "dotnet" binary and SDK folders right in the root
+	result["flat"] = "true"
+
+	return result
+}
+
+// Bins returns list of the all bins included
+// with the distribution of the language
+func (dotnet Dotnet) Bins() []string {
+	return bins
+}
+
+// Dots returns list of the all available filenames
+// which can define versions
+func (dotnet Dotnet) Dots() []string {
+	return dots
+}
+
+// ReadVersion gets version from the ".dotnet-version" file, which "ec -l" writes,
+// or from the "sdk.version" field of the global.json
+func (dotnet Dotnet) ReadVersion(path string) (string, error) {
+	if filepath.Base(path) != "global.json" {
+		return io.ReadVersion(path)
+	}
+
+	data := globalJSON{}
+
+	err := json.Unmarshal([]byte(io.Read(path)), &data)
+	if err != nil {
+		return "", errors.New("Can't parse \"" + path + "\"")
+	}
+
+	return data.SDK.Version, nil
+}
+
+// ListRemote returns list of the all available remote versions
+func (dotnet Dotnet) ListRemote() (result []string, err error) {
+	channels, err := listChannels()
+	if err != nil {
+		return
+	}
+
+	var (
+		rid   = getRid()
+		files = map[string]file{}
+	)
+
+	result = []string{}
+	for _, item := range channels {
+		sdks, errList := listSDKs(item.Releases)
+		if errList != nil {
+			return nil, errList
+		}
+
+		for _, sdk := range sdks {
+			if _, ok := files[sdk.Version]; ok {
+				continue
+			}
+
+			archive, ok := findArchive(sdk.Files, rid)
+			if ok == false {
+				continue
+			}
+
+			files[sdk.Version] = archive
+			result = append(result, sdk.Version)
+		}
+	}
+
+	result = versions.Sort(result)
+
+	err = writeFiles(files)
+
+	return
+}
+
+// listChannels gets all the channels from the releases index
+func listChannels() (result []channel, err error) {
+	body, err := request.Body(VersionLink)
+	if err != nil {
+		return
+	}
+
+	data := struct {
+		Index []channel `json:"releases-index"`
+	}{}
+
+	err = json.Unmarshal([]byte(body), &data)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	for _, item := range data.Index {
+		version, errParse := semver.ParseTolerant(item.Version)
+		if errParse != nil || version.LT(minimalChannel) {
+			continue
+		}
+
+		result = append(result, item)
+	}
+
+	return
+}
+
+// listSDKs gets all SDKs of the channel
+func listSDKs(link string) (result []sdk, err error) {
+	body, err := request.Body(link)
+	if err != nil {
+		return
+	}
+
+	data := struct {
+		Releases []struct {
+			SDK  sdk   `json:"sdk"`
+			SDKs []sdk `json:"sdks"`
+		} `json:"releases"`
+	}{}
+
+	err = json.Unmarshal([]byte(body), &data)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	for _, release := range data.Releases {
+		// Older releases list only one SDK
+		if len(release.SDKs) == 0 {
+			release.SDKs = []sdk{release.SDK}
+		}
+
+		result = append(result, release.SDKs...)
+	}
+
+	return
+}
+
+// findArchive finds the archive of the SDK for the platform
+func findArchive(files []file, rid string) (file, bool) {
+	for _, item := range files {
+		if item.Rid == rid && strings.HasSuffix(item.Name, ".tar.gz") {
+			return item, true
+		}
+	}
+
+	return file{}, false
+}
+
+func filesPath() string {
+	return filepath.Join(variables.Cache(), "dotnet-sdks")
+}
+
+// readFiles gets version to archive map saved by the last ListRemote()
+func readFiles() map[string]file {
+	result := map[string]file{}
+
+	json.Unmarshal([]byte(io.Read(filesPath())), &result)
+
+	return result
+}
+
+func writeFiles(files map[string]file) error {
+	content, err := json.Marshal(files)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	_, err = io.CreateDir(variables.Cache())
+	if err != nil {
+		return err
+	}
+
+	return io.WriteFile(filesPath(), string(content))
+}
+
+// getRid returns runtime identifier of the platform, like "linux-x64"
+func getRid() string {
+	var (
+		osName = runtime.GOOS
+		arch   = "x64"
+	)
+
+	if osName == "darwin" {
+		osName = "osx"
+	}
+
+	if runtime.GOARCH == "arm64" {
+		arch = "arm64"
+	}
+
+	return osName + "-" + arch
+}
@@ -0,0 +1,157 @@
+package dotnet_test
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/markelog/monkey"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	eIO "github.com/markelog/eclectica/io"
+	. "github.com/markelog/eclectica/plugins/dotnet"
+	"github.com/markelog/eclectica/variables"
+)
+
+var _ = Describe("dotnet", func() {
+	var (
+		remotes []string
+		err     error
+		tmp     string
+		ts      *httptest.Server
+	)
+
+	dotnet := &Dotnet{}
+	old := VersionLink
+
+	BeforeEach(func() {
+		tmp, _ = ioutil.TempDir("", "eclectica-dotnet")
+
+		monkey.Patch(variables.Cache, func() string {
+			return tmp
+		})
+
+		var (
+			index    = eIO.Read("./testdata/releases-index.json")
+			releases = eIO.Read("./testdata/releases.json")
+			remote   = "https://dotnetcli.blob.core.windows.net/dotnet/release-metadata"
+		)
+
+		ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasSuffix(r.URL.Path, "releases.json") {
+				io.WriteString(w, releases)
+				return
+			}
+
+			io.WriteString(w, strings.Replace(index, remote, ts.URL, -1))
+		}))
+
+		VersionLink = ts.URL
+	})
+
+	AfterEach(func() {
+		VersionLink = old
+		ts.Close()
+
+		monkey.Unpatch(variables.Cache)
+		os.RemoveAll(tmp)
+	})
+
+	Describe("ListRemote", func() {
+		BeforeEach(func() {
+			remotes, err = dotnet.ListRemote()
+		})
+
+		It("should not return an error", func() {
+			Expect(err).To(BeNil())
+		})
+
+		It("should list SDKs available for the platform", func() {
+			Expect(remotes).To(Equal([]string{"8.0.101", "8.0.100"}))
+		})
+	})
+
+	Describe("Info", func() {
+		BeforeEach(func() {
+			dotnet.ListRemote()
+		})
+
+		It("should get info about 8.0.100 version", func() {
+			result := (&Dotnet{Version: "8.0.100"}).Info()
+
+			Expect(result["flat"]).To(Equal("true"))
+
+			if runtime.GOOS == "linux" && runtime.GOARCH == "amd64" {
+				Expect(result["filename"]).To(Equal("dotnet-sdk-8.0.100-linux-x64"))
+				Expect(result["url"]).To(Equal(
+					"https://download.visualstudio.microsoft.com/download/pr/5226a5fa/dotnet-sdk-8.0.100-linux-x64.tar.gz",
+				))
+				Expect(result["sha512"]).To(HavePrefix("13905ea20191e70b"))
+			}
+		})
+	})
+
+	Describe("PreDownload", func() {
+		It("should get the releases if they're not known", func() {
+			err := (&Dotnet{Version: "8.0.101"}).PreDownload()
+
+			Expect(err).To(BeNil())
+		})
+
+		It("should return an error for unknown version", func() {
+			err := (&Dotnet{Version: "2.2.207"}).PreDownload()
+
+			Expect(err.Error()).To(Equal("Incorrect version 2.2.207"))
+		})
+	})
+
+	Describe("Environment", func() {
+		It("should point DOTNET_ROOT to the version", func() {
+			result, err := (&Dotnet{Version: "8.0.100"}).Environment()
+
+			Expect(err).To(BeNil())
+			Expect(result).To(Equal([]string{
+				"DOTNET_ROOT=" + filepath.Join(variables.Home(), "dotnet", "8.0.100"),
+			}))
+		})
+	})
+
+	Describe("ReadVersion", func() {
+		It("should get version from global.json", func() {
+			version, err := dotnet.ReadVersion("./testdata/global/global.json")
+
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal("8.0.100"))
+		})
+
+		It("should get version from .dotnet-version", func() {
+			path := filepath.Join(tmp, ".dotnet-version")
+			eIO.WriteFile(path, "8.0.100")
+
+			version, err := dotnet.ReadVersion(path)
+
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal("8.0.100"))
+		})
+
+		It("should get system pseudo-version from .dotnet-version", func() {
+			path := filepath.Join(tmp, ".dotnet-version")
+			eIO.WriteFile(path, "system")
+
+			Expect(dotnet.ReadVersion(path)).To(Equal("system"))
+		})
+
+		It("should return nothing if global.json doesn't pin the SDK", func() {
+			version, err := dotnet.ReadVersion("./testdata/empty/global.json")
+
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal(""))
+		})
+	})
+})
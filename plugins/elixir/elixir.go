@@ -0,0 +1,241 @@
+// Package elixir provides all needed logic for installation of Elixir
+package elixir
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/chuckpreslar/emission"
+	"github.com/go-errors/errors"
+
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/pkg"
+	"github.com/markelog/eclectica/plugins/erlang"
+	"github.com/markelog/eclectica/request"
+	"github.com/markelog/eclectica/variables"
+	"github.com/markelog/eclectica/versions"
+)
+
+var (
+	// VersionLink is the URL link from which we can get all possible versions
+	VersionLink = "https://api.github.com/repos/elixir-lang/elixir/tags"
+
+	// DownloadLink from which we download binaries for elixir
+	DownloadLink = "https://github.com/elixir-lang/elixir/releases/download"
+
+	// Precompiled archives for every supported OTP are available only from this version
+	minimalVersion, _ = semver.Make("1.14.0")
+
+	bins = []string{"elixir", "elixirc", "iex", "mix"}
+	dots = []string{".elixir-version", ".tool-versions"}
+
+	rVersion = regexp.MustCompile(`^v(\d+\.\d+\.\d+(-rc\.\d+)?)$`)
+
+	// Like "1.16.0-otp-26" in ".tool-versions"
+	rOTP = regexp.MustCompile(`-otp-\d+$`)
+
+	noErlang = "Elixir needs Erlang, install it first with \"ec erlang\""
+)
+
+// Elixir essential struct
+type Elixir struct {
+	Version string
+	Emitter *emission.Emitter
+
+	// Major version of the Erlang/OTP, it's known only before the download,
+	// since finding it might start the whole erlang VM
+	otp *string
+	pkg.Base
+}
+
+// New returns language struct
+func New(version string, emitter *emission.Emitter) *Elixir {
+	return &Elixir{
+		Version: version,
+		Emitter: emitter,
+		otp:     new(string),
+	}
+}
+
+// Events returns language related event emitter
+func (elixir Elixir) Events() *emission.Emitter {
+	return elixir.Emitter
+}
+
+// PreDownload hook
+func (elixir Elixir) PreDownload() error {
+	otp, err := OTP()
+	if err != nil {
+		return err
+	}
+
+	exists, err := request.Exists(elixir.url(otp))
+	if err != nil {
+		return err
+	}
+
+	if exists == false {
+		return errors.New(fmt.Sprintf(
+			"Elixir %s is not compatible with Erlang/OTP %s", elixir.Version, otp,
+		))
+	}
+
+	if elixir.otp != nil {
+		*elixir.otp = otp
+	}
+
+	return nil
+}
+
+// Info provides all the info needed for installation of the plugin
+func (elixir Elixir) Info() map[string]string {
+	var (
+		result = make(map[string]string)
+		otp    = ""
+	)
+
+	// Proxies construct the plugin on every call, so it's not looked up here
+	if elixir.otp != nil {
+		otp = *elixir.otp
+	}
+
+	result["filename"] = "elixir-otp-" + otp
+	result["extension"] = "zip"
+	result["url"] = elixir.url(otp)
+
+	// Precompiled archive has "bin" and "lib" folders right in the root
+	result["flat"] = "true"
+
+	return result
+}
+
+// Bins returns list of the all bins included
+// with the distribution of the language
+func (elixir Elixir) Bins() []string {
+	return bins
+}
+
+// Dots returns list of the all available filenames
+// which can define versions
+func (elixir Elixir) Dots() []string {
+	return dots
+}
+
+// ReadVersion gets version from the ".elixir-version" or ".tool-versions" files
+func (elixir Elixir) ReadVersion(path string) (string, error) {
+	var version string
+
+	if filepath.Base(path) == ".tool-versions" {
+		version = io.ReadToolVersion(path, "elixir")
+	} else {
+		content := strings.TrimSpace(io.Read(path))
+		version = strings.Split(content, "\n")[0]
+	}
+
+	version = rOTP.ReplaceAllString(strings.TrimSpace(version), "")
+	if version == "" {
+		return "", nil
+	}
+
+	if rVersion.MatchString("v" + version) {
+		return version, nil
+	}
+
+	return io.ExtractVersion(version)
+}
+
+// ListRemote returns list of the all available remote versions
+func (elixir Elixir) ListRemote() (result []string, err error) {
+	tags, err := request.GitHubTags(VersionLink)
+	if err != nil {
+		return
+	}
+
+	result = []string{}
+	for _, tag := range tags {
+		match := rVersion.FindStringSubmatch(tag)
+		if len(match) == 0 {
+			continue
+		}
+
+		version, errParse := semver.Make(match[1])
+		if errParse != nil || version.LT(minimalVersion) {
+			continue
+		}
+
+		result = append(result, match[1])
+	}
+
+	return
+}
+
+func (elixir Elixir) url(otp string) string {
+	return fmt.Sprintf("%s/v%s/elixir-otp-%s.zip", DownloadLink, elixir.Version, otp)
+}
+
+// OTP gets major version of the Erlang/OTP which elixir would be executed with,
+// returns an error if there is no such Erlang
+func OTP() (string, error) {
+	version, err := erlangVersion()
+	if err != nil {
+		return "", err
+	}
+
+	// Erlang is either not installed by eclectica at all or user prefers the OS one
+	if version == "" || version == "system" {
+		return systemOTP()
+	}
+
+	if variables.IsInstalled("erlang", version) == false {
+		return "", errors.New(noErlang)
+	}
+
+	return strings.Split(version, ".")[0], nil
+}
+
+// erlangVersion gets the version of erlang the same way proxy would
+func erlangVersion() (string, error) {
+	var (
+		plugin = erlang.New("", nil)
+		pwd, _ = os.Getwd()
+	)
+
+	version, _, err := io.FindVersion(plugin.Dots(), pwd, plugin.ReadVersion)
+	if err != nil {
+		return "", err
+	}
+
+	if version == "current" {
+		return variables.CurrentVersion("erlang"), nil
+	}
+
+	if versions.IsPartial(version) {
+		installed := io.ListVersions(variables.Prefix("erlang"))
+
+		return versions.Latest(version, installed)
+	}
+
+	return version, nil
+}
+
+// systemOTP asks erlang of the OS for its version
+func systemOTP() (string, error) {
+	erl, err := io.LookPath("erl", variables.Base(), variables.DefaultInstall)
+	if err != nil {
+		return "", errors.New(noErlang)
+	}
+
+	out, err := exec.Command(
+		erl, "-noshell", "-eval", `io:format("~s", [erlang:system_info(otp_release)]), halt().`,
+	).Output()
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,13 @@
+package elixir_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestElixir(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Elixir Suite")
+}
@@ -0,0 +1,184 @@
+package elixir_test
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/markelog/monkey"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	eIO "github.com/markelog/eclectica/io"
+	. "github.com/markelog/eclectica/plugins/elixir"
+	"github.com/markelog/eclectica/variables"
+)
+
+var _ = Describe("elixir", func() {
+	var (
+		remotes []string
+		err     error
+		tmp     string
+	)
+
+	elixir := &Elixir{}
+
+	// Makes erlang of the version current one, installed or not
+	useErlang := func(version string, installed bool) {
+		os.MkdirAll(filepath.Join(tmp, "erlang", "current"), 0755)
+		eIO.WriteFile(filepath.Join(tmp, "erlang", "current", ".eclectica"), version)
+
+		if installed {
+			os.MkdirAll(filepath.Join(tmp, "erlang", version), 0755)
+			eIO.WriteFile(filepath.Join(tmp, "erlang", version, ".eclectica"), version)
+		}
+	}
+
+	BeforeEach(func() {
+		tmp, _ = ioutil.TempDir("", "eclectica-elixir")
+
+		monkey.Patch(variables.Home, func() string {
+			return tmp
+		})
+	})
+
+	AfterEach(func() {
+		monkey.Unpatch(variables.Home)
+		os.RemoveAll(tmp)
+	})
+
+	Describe("ListRemote", func() {
+		old := VersionLink
+
+		BeforeEach(func() {
+			content := eIO.Read("./testdata/tags.json")
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				io.WriteString(w, content)
+			}))
+
+			VersionLink = ts.URL
+
+			remotes, err = elixir.ListRemote()
+		})
+
+		AfterEach(func() {
+			VersionLink = old
+		})
+
+		It("should not return an error", func() {
+			Expect(err).To(BeNil())
+		})
+
+		It("should have versions with the precompiled archives", func() {
+			Expect(remotes).To(Equal([]string{"1.16.1", "1.16.0", "1.16.0-rc.1", "1.14.0"}))
+		})
+	})
+
+	Describe("OTP", func() {
+		It("should get major version of the current erlang", func() {
+			useErlang("26.2.1", true)
+
+			otp, err := OTP()
+
+			Expect(err).To(BeNil())
+			Expect(otp).To(Equal("26"))
+		})
+
+		It("should return an error if erlang is not installed", func() {
+			useErlang("26.2.1", false)
+
+			_, err := OTP()
+
+			Expect(err).To(MatchError("Elixir needs Erlang, install it first with \"ec erlang\""))
+		})
+	})
+
+	Describe("Info", func() {
+		It("should not ask erlang of the OS for its version", func() {
+			called := false
+
+			monkey.Patch(eIO.LookPath, func(name string, exclude ...string) (string, error) {
+				return "/usr/bin/" + name, nil
+			})
+			defer monkey.Unpatch(eIO.LookPath)
+
+			monkey.Patch(exec.Command, func(name string, arg ...string) *exec.Cmd {
+				called = true
+				return &exec.Cmd{}
+			})
+			defer monkey.Unpatch(exec.Command)
+
+			result := New("1.16.0", nil).Info()
+
+			Expect(called).To(Equal(false))
+			Expect(result["extension"]).To(Equal("zip"))
+			Expect(result["flat"]).To(Equal("true"))
+		})
+	})
+
+	Describe("PreDownload", func() {
+		old := DownloadLink
+
+		BeforeEach(func() {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v1.16.0/elixir-otp-26.zip" {
+					w.WriteHeader(404)
+				}
+			}))
+
+			DownloadLink = ts.URL
+		})
+
+		AfterEach(func() {
+			DownloadLink = old
+		})
+
+		It("should pass for the compatible erlang", func() {
+			useErlang("26.2.1", true)
+
+			Expect((&Elixir{Version: "1.16.0"}).PreDownload()).To(BeNil())
+		})
+
+		It("should get archive for the current erlang", func() {
+			useErlang("26.2.1", true)
+
+			elixir := New("1.16.0", nil)
+
+			Expect(elixir.PreDownload()).To(BeNil())
+
+			result := elixir.Info()
+
+			Expect(result["filename"]).To(Equal("elixir-otp-26"))
+			Expect(result["url"]).To(Equal(DownloadLink + "/v1.16.0/elixir-otp-26.zip"))
+		})
+
+		It("should return an error for the incompatible erlang", func() {
+			useErlang("21.0.0", true)
+
+			err := (&Elixir{Version: "1.16.0"}).PreDownload()
+
+			Expect(err).To(MatchError("Elixir 1.16.0 is not compatible with Erlang/OTP 21"))
+		})
+	})
+
+	Describe("ReadVersion", func() {
+		It("should read \".elixir-version\"", func() {
+			version, err := elixir.ReadVersion("./testdata/dot/.elixir-version")
+
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal("1.16.0-rc.1"))
+		})
+
+		It("should read elixir entry of the \".tool-versions\" without OTP part", func() {
+			version, err := elixir.ReadVersion("./testdata/tool-versions/.tool-versions")
+
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal("1.16.0"))
+		})
+	})
+})
@@ -2,35 +2,49 @@
 package elm
 
 import (
+	"encoding/json"
 	"fmt"
-	"net"
 	"os"
 	"path/filepath"
 	"regexp"
 	"runtime"
-	"strings"
 
-	"github.com/PuerkitoBio/goquery"
 	"github.com/blang/semver"
 	"github.com/chuckpreslar/emission"
 	"github.com/go-errors/errors"
-	"github.com/markelog/cprf"
 
 	"github.com/markelog/eclectica/io"
 	"github.com/markelog/eclectica/pkg"
+	"github.com/markelog/eclectica/request"
 	"github.com/markelog/eclectica/variables"
 )
 
 var (
 	// VersionLink is the URL link from which we can get all possible versions
-	VersionLink = "https://dl.bintray.com/elmlang/elm-platform"
+	VersionLink = "https://api.github.com/repos/elm/compiler/tags"
 
-	versionPattern = "\\d+\\.\\d+\\.\\d+"
+	// DownloadLink from which we download binaries for elm
+	DownloadLink = "https://github.com/elm/compiler/releases/download"
 
-	diffFolderBinaryName, _ = semver.Make("0.17.1")
+	rVersion = regexp.MustCompile(`^\d+\.\d+\.\d+$`)
 
-	bins = []string{"elm", "elm-make", "elm-package", "elm-reactor", "elm-repl"}
-	dots = []string{".elm-version"}
+	// Since this version elm is a single binary, archives of the older ones
+	// were hosted on bintray which is gone, so they can't be installed anymore
+	singleBinary, _ = semver.Make("0.19.0")
+
+	// Constraint of the packages, like "0.19.0 <= v < 0.20.0"
+	rConstraint = regexp.MustCompile(`^(\d+\.\d+\.\d+)\s*<=?\s*v`)
+
+	// Platforms as they are named in the releases
+	platforms = map[string]string{
+		"linux-amd64":  "linux-64-bit",
+		"darwin-amd64": "mac-64-bit",
+		"darwin-arm64": "mac-64-bit-ARM",
+	}
+
+	bins       = []string{"elm"}
+	legacyBins = []string{"elm", "elm-make", "elm-package", "elm-reactor", "elm-repl"}
+	dots       = []string{".elm-version", "elm.json"}
 )
 
 // Elm essential struct
@@ -55,6 +69,15 @@ func (elm Elm) Events() *emission.Emitter {
 
 // PreDownload hook
 func (elm Elm) PreDownload() (err error) {
+	if elm.isLegacy() {
+		return errors.New("Versions of elm before " + singleBinary.String() + " are not available anymore")
+	}
+
+	_, err = getBinaryName()
+	if err != nil {
+		return
+	}
+
 	path := elm.getTmpPath()
 
 	if _, errStat := os.Stat(path); os.IsNotExist(errStat) {
@@ -74,15 +97,14 @@ func (elm Elm) PostInstall() error {
 		return err
 	}
 
-	for _, name := range elm.Bins() {
-		currentBinPath := filepath.Join(path, name)
-
-		cprf.Copy(currentBinPath, binPath)
+	name, err := getBinaryName()
+	if err != nil {
+		return err
+	}
 
-		err = os.RemoveAll(currentBinPath)
-		if err != nil {
-			return err
-		}
+	err = os.Rename(filepath.Join(path, name), filepath.Join(binPath, "elm"))
+	if err != nil {
+		return errors.New(err)
 	}
 
 	return nil
@@ -90,36 +112,25 @@ func (elm Elm) PostInstall() error {
 
 // Info provides all the info needed for installation of the plugin
 func (elm Elm) Info() map[string]string {
-	var (
-		result     = make(map[string]string)
-		sourcesURL = fmt.Sprintf("%s/%s", VersionLink, elm.Version)
-		chosen, _  = semver.Make(elm.Version)
-	)
-
-	// Man, why?!
-	if chosen.LT(diffFolderBinaryName) {
-		result["unarchive-filename"] = "dist_binaries"
-	}
-	if runtime.GOOS == "linux" {
-		result["unarchive-filename"] = "dist_binaries"
-	}
-	if elm.Version == "0.15.1" && runtime.GOOS == "darwin" {
-		result["unarchive-filename"] = "osx"
-	}
-	if elm.Version == "0.15.1" && runtime.GOOS == "linux" {
-		result["unarchive-filename"] = "linux64"
-	}
+	result := make(map[string]string)
 
-	result["filename"] = fmt.Sprintf("%s-x64", runtime.GOOS)
-	result["url"] = fmt.Sprintf("%s/%s.tar.gz", sourcesURL, result["filename"])
-	result["archive-folder"] = filepath.Join(variables.TempDir(), "elm-archive-"+elm.Version) + "/"
+	result["filename"], _ = getBinaryName()
+	result["extension"] = "gz"
+	result["url"] = fmt.Sprintf("%s/%s/%s.gz", DownloadLink, elm.Version, result["filename"])
+	result["archive-folder"] = elm.getTmpPath()
+	result["unarchive-filename"] = "elm-" + elm.Version
+	result["flat"] = "true"
 
 	return result
 }
 
-// Bins returns list of the all bins included
-// with the distribution of the language
+// Bins returns list of the all bins included with the distribution
+// of the language, old versions might still be installed
 func (elm Elm) Bins() []string {
+	if elm.isLegacy() {
+		return legacyBins
+	}
+
 	return bins
 }
 
@@ -129,32 +140,83 @@ func (elm Elm) Dots() []string {
 	return dots
 }
 
-// ListRemote returns list of the all available remote versions
-func (elm Elm) ListRemote() ([]string, error) {
-	doc, err := goquery.NewDocument(VersionLink)
+// ReadVersion gets version from the ".elm-version" file or from the
+// "elm-version" field of "elm.json", which is a range for the packages
+func (elm Elm) ReadVersion(path string) (string, error) {
+	if filepath.Base(path) != "elm.json" {
+		return io.ReadVersion(path)
+	}
+
+	project := struct {
+		Version string `json:"elm-version"`
+	}{}
 
+	err := json.Unmarshal([]byte(io.Read(path)), &project)
 	if err != nil {
-		if _, ok := err.(net.Error); ok {
-			return nil, errors.New(variables.ConnectionError)
-		}
+		return "", errors.New("Can't parse \"" + path + "\"")
+	}
+
+	if rVersion.MatchString(project.Version) {
+		return project.Version, nil
+	}
 
-		return nil, err
+	// Any patch of the lowest version of the range will do, like "0.19"
+	match := rConstraint.FindStringSubmatch(project.Version)
+	if len(match) == 0 {
+		return "", nil
 	}
 
-	result := []string{}
-	rVersion := regexp.MustCompile(versionPattern)
+	lowest, _ := semver.Make(match[1])
+
+	return fmt.Sprintf("%d.%d", lowest.Major, lowest.Minor), nil
+}
+
+// ListRemote returns list of the all available remote versions
+func (elm Elm) ListRemote() (result []string, err error) {
+	tags, err := request.GitHubTags(VersionLink)
+	if err != nil {
+		return
+	}
 
-	doc.Find("a").Each(func(i int, elm *goquery.Selection) {
-		value := elm.Text()
+	result = []string{}
+	for _, tag := range tags {
+		if rVersion.MatchString(tag) == false {
+			continue
+		}
 
-		if rVersion.MatchString(value) {
-			result = append(result, strings.Replace(value, "/", "", 1))
+		version, _ := semver.Make(tag)
+		if version.LT(singleBinary) {
+			continue
 		}
-	})
 
-	return result, nil
+		result = append(result, tag)
+	}
+
+	return
 }
 
 func (elm Elm) getTmpPath() string {
 	return filepath.Join(variables.TempDir(), "elm-archive-"+elm.Version) + "/"
 }
+
+// isLegacy checks if version was distributed as archive with the bunch of binaries
+func (elm Elm) isLegacy() bool {
+	chosen, err := semver.Make(elm.Version)
+	if err != nil {
+		return false
+	}
+
+	return chosen.LT(singleBinary)
+}
+
+// getBinaryName returns name of the binary as releases have it, like "binary-for-linux-64-bit"
+func getBinaryName() (string, error) {
+	platform := runtime.GOOS + "-" + runtime.GOARCH
+
+	name, ok := platforms[platform]
+	if ok == false {
+		return "", errors.New("Not supported environment \"" + platform + "\"")
+	}
+
+	return "binary-for-" + name, nil
+}
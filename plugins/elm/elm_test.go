@@ -1,7 +1,6 @@
 package elm_test
 
 import (
-	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -32,16 +31,9 @@ var _ = Describe("elm", func() {
 
 		Describe("success", func() {
 			BeforeEach(func() {
-				content := eIO.Read("../../testdata/plugins/elm/elm-platform.html")
+				content := eIO.Read("./testdata/tags.json")
 
 				ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-					status := 200
-
-					if _, ok := r.URL.Query()["status"]; ok {
-						fmt.Sscanf(r.URL.Query().Get("status"), "%d", &status)
-					}
-
-					w.WriteHeader(status)
 					io.WriteString(w, content)
 				}))
 
@@ -55,13 +47,17 @@ var _ = Describe("elm", func() {
 			})
 
 			It("should have correct version values", func() {
-				Expect(remotes[0]).To(Equal("0.15.1"))
+				Expect(remotes).To(Equal([]string{"0.19.1", "0.19.0"}))
 			})
 		})
 
 		Describe("fail", func() {
 			BeforeEach(func() {
-				VersionLink = ""
+				ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(500)
+				}))
+
+				VersionLink = ts.URL
 				remotes, err = elm.ListRemote()
 			})
 
@@ -72,64 +68,59 @@ var _ = Describe("elm", func() {
 	})
 
 	Describe("Info", func() {
-		It("should get info about 0.18.0 version", func() {
-			result := (&Elm{Version: "0.18.0"}).Info()
-
-			Expect(result["archive-folder"]).Should(ContainSubstring("elm-archive-0.18.0/"))
-
-			// :/
-			if runtime.GOOS == "darwin" {
-				Expect(result["unarchive-filename"]).To(Equal(""))
-				Expect(result["filename"]).To(Equal("darwin-x64"))
-				Expect(result["url"]).To(Equal("https://dl.bintray.com/elmlang/elm-platform/0.18.0/darwin-x64.tar.gz"))
-			} else if runtime.GOOS == "linux" {
-				Expect(result["unarchive-filename"]).To(Equal("dist_binaries"))
-				Expect(result["filename"]).To(Equal("linux-x64"))
-				Expect(result["url"]).To(Equal("https://dl.bintray.com/elmlang/elm-platform/0.18.0/linux-x64.tar.gz"))
+		It("should get info about 0.19.1 version", func() {
+			result := (&Elm{Version: "0.19.1"}).Info()
+
+			Expect(result["archive-folder"]).Should(ContainSubstring("elm-archive-0.19.1/"))
+			Expect(result["extension"]).To(Equal("gz"))
+			Expect(result["flat"]).To(Equal("true"))
+
+			if runtime.GOOS == "linux" && runtime.GOARCH == "amd64" {
+				Expect(result["filename"]).To(Equal("binary-for-linux-64-bit"))
+				Expect(result["url"]).To(Equal(
+					"https://github.com/elm/compiler/releases/download/0.19.1/binary-for-linux-64-bit.gz",
+				))
 			}
 		})
+	})
 
-		It("should get info about 0.17.1 version", func() {
-			result := (&Elm{Version: "0.17.1"}).Info()
+	Describe("PreDownload", func() {
+		It("should return an error for versions before 0.19", func() {
+			err := (&Elm{Version: "0.18.0"}).PreDownload()
 
-			Expect(result["archive-folder"]).Should(ContainSubstring("elm-archive-0.17.1/"))
+			Expect(err).To(MatchError("Versions of elm before 0.19.0 are not available anymore"))
+		})
 
-			// :/
-			if runtime.GOOS == "darwin" {
-				Expect(result["unarchive-filename"]).To(Equal(""))
-				Expect(result["filename"]).To(Equal("darwin-x64"))
-				Expect(result["url"]).To(Equal("https://dl.bintray.com/elmlang/elm-platform/0.17.1/darwin-x64.tar.gz"))
-			} else if runtime.GOOS == "linux" {
-				Expect(result["unarchive-filename"]).To(Equal("dist_binaries"))
-				Expect(result["filename"]).To(Equal("linux-x64"))
-				Expect(result["url"]).To(Equal("https://dl.bintray.com/elmlang/elm-platform/0.17.1/linux-x64.tar.gz"))
+		It("should not return an error for supported platforms", func() {
+			if runtime.GOOS == "linux" && runtime.GOARCH == "amd64" {
+				Expect((&Elm{Version: "0.19.1"}).PreDownload()).To(BeNil())
 			}
 		})
+	})
 
-		It("should get info about 0.17.0 version", func() {
-			result := (&Elm{Version: "0.17.0"}).Info()
+	Describe("Bins", func() {
+		It("should have only one binary since 0.19", func() {
+			Expect((&Elm{Version: "0.19.1"}).Bins()).To(Equal([]string{"elm"}))
+		})
 
-			// :/
-			if runtime.GOOS == "darwin" {
-				Expect(result["unarchive-filename"]).To(Equal("dist_binaries"))
-				Expect(result["url"]).To(Equal("https://dl.bintray.com/elmlang/elm-platform/0.17.0/darwin-x64.tar.gz"))
-			} else if runtime.GOOS == "linux" {
-				Expect(result["unarchive-filename"]).To(Equal("dist_binaries"))
-				Expect(result["url"]).To(Equal("https://dl.bintray.com/elmlang/elm-platform/0.17.0/linux-x64.tar.gz"))
-			}
+		It("should have all the binaries before 0.19", func() {
+			Expect((&Elm{Version: "0.18.0"}).Bins()).To(ContainElement("elm-make"))
+		})
+	})
+
+	Describe("ReadVersion", func() {
+		It("should read version of the application", func() {
+			Expect(elm.ReadVersion("./testdata/application/elm.json")).To(Equal("0.19.1"))
+		})
+
+		It("should read lowest version of the package range", func() {
+			Expect(elm.ReadVersion("./testdata/package/elm.json")).To(Equal("0.19"))
 		})
 
-		It("should get info about 0.15.1 version", func() {
-			result := (&Elm{Version: "0.15.1"}).Info()
+		It("should return an error for broken file", func() {
+			_, err := elm.ReadVersion("./testdata/broken/elm.json")
 
-			// :/
-			if runtime.GOOS == "darwin" {
-				Expect(result["unarchive-filename"]).To(Equal("osx"))
-				Expect(result["url"]).To(Equal("https://dl.bintray.com/elmlang/elm-platform/0.15.1/darwin-x64.tar.gz"))
-			} else if runtime.GOOS == "linux" {
-				Expect(result["unarchive-filename"]).To(Equal("linux64"))
-				Expect(result["url"]).To(Equal("https://dl.bintray.com/elmlang/elm-platform/0.15.1/linux-x64.tar.gz"))
-			}
+			Expect(err).To(HaveOccurred())
 		})
 	})
 })
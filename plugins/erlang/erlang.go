@@ -0,0 +1,229 @@
+// Package erlang provides all needed logic for installation of Erlang/OTP
+package erlang
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/chuckpreslar/emission"
+	"github.com/go-errors/errors"
+	hversion "github.com/hashicorp/go-version"
+
+	"github.com/markelog/eclectica/compile"
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/pkg"
+	"github.com/markelog/eclectica/request"
+	"github.com/markelog/eclectica/variables"
+)
+
+var (
+	// VersionLink is the URL link from which we can get all possible versions
+	VersionLink = "https://api.github.com/repos/erlang/otp/tags"
+
+	// DownloadLink from which we download sources of the erlang
+	DownloadLink = "https://github.com/erlang/otp/releases/download"
+
+	// ConfigureOptions is the name of the environment variable with additional
+	// options for the "configure", same one kerl uses
+	ConfigureOptions = "KERL_CONFIGURE_OPTIONS"
+
+	// Source tarballs are attached to the GitHub releases only from this version
+	minimalVersion, _ = hversion.NewVersion("21.0.0")
+
+	bins = []string{
+		"ct_run", "dialyzer", "epmd", "erl", "erlc", "escript", "run_erl", "to_erl", "typer",
+	}
+	dots = []string{".erlang-version", ".tool-versions"}
+
+	// Maintenance releases have four numbers, like "OTP-25.3.2.8"
+	rVersion     = regexp.MustCompile(`^OTP-(\d+(\.\d+){1,3})$`)
+	rFileVersion = regexp.MustCompile(`\d+(\.\d+){0,3}`)
+)
+
+// Erlang essential struct
+type Erlang struct {
+	Version string
+	Emitter *emission.Emitter
+	pkg.Base
+}
+
+// New returns language struct
+func New(version string, emitter *emission.Emitter) *Erlang {
+	return &Erlang{
+		Version: version,
+		Emitter: emitter,
+	}
+}
+
+// Events returns language related event emitter
+func (erlang Erlang) Events() *emission.Emitter {
+	return erlang.Emitter
+}
+
+// PreInstall hook
+func (erlang Erlang) PreInstall() (err error) {
+	var (
+		install = variables.InstallLanguage("erlang", erlang.Version)
+		current = variables.Path("erlang", erlang.Version)
+	)
+
+	// Just in case
+	os.RemoveAll(install)
+
+	_, err = io.CreateDir(filepath.Dir(install))
+	if err != nil {
+		return
+	}
+
+	err = os.Rename(current, install)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	return
+}
+
+// Install hook
+func (erlang Erlang) Install() (err error) {
+	source := compile.New(variables.InstallLanguage("erlang", erlang.Version), erlang.Emitter)
+
+	err = source.Configure(erlang.configureArgs()...)
+	if err != nil {
+		return
+	}
+
+	err = source.Prepare()
+	if err != nil {
+		return
+	}
+
+	return source.Install()
+}
+
+// PostInstall hook
+func (erlang Erlang) PostInstall() (err error) {
+	return os.RemoveAll(filepath.Join(variables.InstallPath(), "erlang"))
+}
+
+// Rollback hook
+func (erlang Erlang) Rollback() (err error) {
+	return os.RemoveAll(filepath.Join(variables.InstallPath(), "erlang"))
+}
+
+// Info provides all the info needed for installation of the plugin
+func (erlang Erlang) Info() map[string]string {
+	var (
+		result = make(map[string]string)
+		tag    = remoteVersion(erlang.Version)
+	)
+
+	result["filename"] = "otp_src_" + tag
+	result["url"] = fmt.Sprintf("%s/OTP-%s/%s.tar.gz", DownloadLink, tag, result["filename"])
+
+	return result
+}
+
+// Bins returns list of the all bins included
+// with the distribution of the language
+func (erlang Erlang) Bins() []string {
+	return bins
+}
+
+// Dots returns list of the all available filenames
+// which can define versions
+func (erlang Erlang) Dots() []string {
+	return dots
+}
+
+// ReadVersion gets version from the ".erlang-version" or ".tool-versions" files
+func (erlang Erlang) ReadVersion(path string) (string, error) {
+	version := ""
+
+	if filepath.Base(path) == ".tool-versions" {
+		version = io.ReadToolVersion(path, "erlang")
+	} else {
+		version = strings.Split(strings.TrimSpace(io.Read(path)), "\n")[0]
+	}
+
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return "", nil
+	}
+
+	// io.ExtractVersion would drop the fourth number of the maintenance release
+	if match := rFileVersion.FindString(version); match != "" {
+		return match, nil
+	}
+
+	return io.ExtractVersion(version)
+}
+
+// ListRemote returns list of the all available remote versions
+func (erlang Erlang) ListRemote() (result []string, err error) {
+	tags, err := request.GitHubTags(VersionLink)
+	if err != nil {
+		return
+	}
+
+	result = []string{}
+	for _, tag := range tags {
+		match := rVersion.FindStringSubmatch(tag)
+		if len(match) == 0 {
+			continue
+		}
+
+		// First release of the minor version doesn't have the patch part
+		version := match[1]
+		if strings.Count(version, ".") == 1 {
+			version += ".0"
+		}
+
+		parsed, errParse := hversion.NewVersion(version)
+		if errParse != nil || parsed.LessThan(minimalVersion) {
+			continue
+		}
+
+		result = append(result, version)
+	}
+
+	return
+}
+
+// configureArgs gets arguments for the "configure",
+// user might add more of them in the same way as for kerl
+func (erlang Erlang) configureArgs() []string {
+	var (
+		options = strings.Fields(os.Getenv(ConfigureOptions))
+		prefix  = "--prefix=" + variables.Path("erlang", erlang.Version)
+		result  = append([]string{prefix}, options...)
+	)
+
+	if runtime.GOOS != "darwin" || strings.Contains(strings.Join(options, " "), "--with-ssl") {
+		return result
+	}
+
+	// OpenSSL of the macOS is not usable for the crypto application
+	out, err := exec.Command("brew", "--prefix", "openssl").Output()
+	if err != nil {
+		return result
+	}
+
+	return append(result, "--with-ssl="+strings.TrimSpace(string(out)))
+}
+
+// remoteVersion is how version is named in the tags,
+// which do not have the patch part for the new minor, like "OTP-26.2"
+func remoteVersion(version string) string {
+	parts := strings.Split(version, ".")
+
+	if len(parts) == 3 && parts[2] == "0" {
+		return strings.Join(parts[:2], ".")
+	}
+
+	return version
+}
@@ -0,0 +1,13 @@
+package erlang_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestErlang(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Erlang Suite")
+}
@@ -0,0 +1,119 @@
+package erlang_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	eIO "github.com/markelog/eclectica/io"
+	. "github.com/markelog/eclectica/plugins/erlang"
+	"github.com/markelog/eclectica/variables"
+)
+
+var _ = Describe("erlang", func() {
+	var (
+		remotes []string
+		err     error
+	)
+
+	erlang := &Erlang{}
+
+	Describe("ListRemote", func() {
+		old := VersionLink
+
+		AfterEach(func() {
+			VersionLink = old
+		})
+
+		Describe("success", func() {
+			BeforeEach(func() {
+				content := eIO.Read("./testdata/tags.json")
+
+				ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					io.WriteString(w, content)
+				}))
+
+				VersionLink = ts.URL
+
+				remotes, err = erlang.ListRemote()
+			})
+
+			It("should not return an error", func() {
+				Expect(err).To(BeNil())
+			})
+
+			It("should have only released versions with the patch part", func() {
+				Expect(remotes).To(Equal([]string{
+					"26.2.5.3", "26.2.1", "26.2.0", "26.0.0", "25.3.2.8", "25.3.0", "21.0.0",
+				}))
+			})
+		})
+
+		Describe("fail", func() {
+			BeforeEach(func() {
+				ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(500)
+				}))
+
+				VersionLink = ts.URL
+
+				remotes, err = erlang.ListRemote()
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(MatchError(variables.ConnectionError))
+			})
+		})
+	})
+
+	Describe("Info", func() {
+		It("should get info about maintenance release", func() {
+			result := (&Erlang{Version: "26.2.5.3"}).Info()
+
+			Expect(result["filename"]).To(Equal("otp_src_26.2.5.3"))
+			Expect(result["url"]).To(Equal(
+				"https://github.com/erlang/otp/releases/download/OTP-26.2.5.3/otp_src_26.2.5.3.tar.gz",
+			))
+		})
+
+		It("should get info about 26.2.1 version", func() {
+			result := (&Erlang{Version: "26.2.1"}).Info()
+
+			Expect(result["filename"]).To(Equal("otp_src_26.2.1"))
+			Expect(result["url"]).To(Equal("https://github.com/erlang/otp/releases/download/OTP-26.2.1/otp_src_26.2.1.tar.gz"))
+		})
+
+		It("should get info about the first release of the minor version", func() {
+			result := (&Erlang{Version: "26.2.0"}).Info()
+
+			Expect(result["filename"]).To(Equal("otp_src_26.2"))
+			Expect(result["url"]).To(Equal("https://github.com/erlang/otp/releases/download/OTP-26.2/otp_src_26.2.tar.gz"))
+		})
+	})
+
+	Describe("ReadVersion", func() {
+		It("should read \".erlang-version\"", func() {
+			version, err := erlang.ReadVersion("./testdata/dot/.erlang-version")
+
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal("25.3"))
+		})
+
+		It("should keep all four numbers of the maintenance release", func() {
+			version, err := erlang.ReadVersion("./testdata/maintenance/.erlang-version")
+
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal("26.2.5.3"))
+		})
+
+		It("should read erlang entry of the \".tool-versions\"", func() {
+			version, err := erlang.ReadVersion("./testdata/tool-versions/.tool-versions")
+
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal("26.2.1"))
+		})
+	})
+})
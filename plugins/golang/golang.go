@@ -78,6 +78,9 @@ func (golang Golang) Environment() (result []string, err error) {
 		result = append(result, "GOPATH="+filepath.Join(os.Getenv("HOME"), "go"))
 	}
 
+	// So "go install" would put executables where proxies can find them
+	result = append(result, "GOBIN="+filepath.Join(variables.Path("go", golang.Version), "bin"))
+
 	return
 }
 
@@ -208,6 +208,7 @@ var _ = Describe("golang", func() {
 
 			Expect(result[0]).To(Equal("GOROOT=.eclectica/versions/go"))
 			Expect(result[1]).To(Equal("GOPATH=go"))
+			Expect(result[2]).To(Equal("GOBIN=.eclectica/versions/go/bin"))
 
 			monkey.Unpatch(os.Getenv)
 			monkey.Unpatch(user.Current)
@@ -231,8 +232,9 @@ var _ = Describe("golang", func() {
 
 			result, _ := golang.Environment()
 
-			Expect(len(result)).To(Equal(1))
+			Expect(len(result)).To(Equal(2))
 			Expect(result[0]).To(Equal("GOROOT=.eclectica/versions/go"))
+			Expect(result[1]).To(Equal("GOBIN=.eclectica/versions/go/bin"))
 
 			monkey.Unpatch(os.Getenv)
 			monkey.Unpatch(user.Current)
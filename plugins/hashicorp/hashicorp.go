@@ -0,0 +1,351 @@
+// Package hashicorp provides all needed logic for installation of the HashiCorp tools,
+// all of them are released in the same way, so a new tool only needs its name
+package hashicorp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/chuckpreslar/emission"
+	"github.com/go-errors/errors"
+
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/pkg"
+	"github.com/markelog/eclectica/request"
+	"github.com/markelog/eclectica/variables"
+	"github.com/markelog/eclectica/versions"
+)
+
+var (
+	// VersionLink is the URL of the releases from which we get all possible versions
+	VersionLink = "https://releases.hashicorp.com"
+
+	// Like "~> 1.6.0" or ">= 1.2"
+	rConstraint = regexp.MustCompile(`^(=|!=|>=|<=|>|<|~>)?\s*v?(\d+(\.\d+){0,2}(-[0-9A-Za-z.-]+)?)$`)
+)
+
+// Tool essential struct
+type Tool struct {
+	Name    string
+	Version string
+	Emitter *emission.Emitter
+	pkg.Base
+}
+
+// release is how index describes the version
+type release struct {
+	Version string  `json:"version"`
+	Shasums string  `json:"shasums"`
+	Builds  []build `json:"builds"`
+}
+
+// build is how index describes the archive of the version
+type build struct {
+	OS       string `json:"os"`
+	Arch     string `json:"arch"`
+	Filename string `json:"filename"`
+	URL      string `json:"url"`
+	Shasums  string `json:"shasums"`
+	Sum      string `json:"sum"`
+}
+
+// New returns tool struct
+func New(name, version string, emitter *emission.Emitter) *Tool {
+	return &Tool{
+		Name:    name,
+		Version: version,
+		Emitter: emitter,
+	}
+}
+
+// Events returns tool related event emitter
+func (tool Tool) Events() *emission.Emitter {
+	return tool.Emitter
+}
+
+// PreDownload hook
+func (tool Tool) PreDownload() (err error) {
+	builds := tool.readBuilds()
+
+	// Archives are known only after we get the list
+	if _, ok := builds[tool.Version]; ok == false {
+		_, err = tool.ListRemote()
+		if err != nil {
+			return
+		}
+
+		builds = tool.readBuilds()
+	}
+
+	item, ok := builds[tool.Version]
+	if ok == false {
+		return errors.New("Incorrect version " + tool.Version)
+	}
+
+	if item.Sum != "" {
+		return
+	}
+
+	item.Sum, err = tool.getSum(item)
+	if err != nil {
+		return
+	}
+
+	builds[tool.Version] = item
+
+	return tool.writeBuilds(builds)
+}
+
+// Install hook
+func (tool Tool) Install() (err error) {
+	var (
+		base = variables.Path(tool.Name, tool.Version)
+		bin  = filepath.Join(base, "bin")
+	)
+
+	// Already moved
+	if _, errStat := os.Stat(filepath.Join(bin, tool.Name)); errStat == nil {
+		return
+	}
+
+	_, err = io.CreateDir(bin)
+	if err != nil {
+		return
+	}
+
+	// Archive has only the binary and maybe a license
+	err = os.Rename(filepath.Join(base, tool.Name), filepath.Join(bin, tool.Name))
+	if err != nil {
+		return errors.New(err)
+	}
+
+	return
+}
+
+// Info provides all the info needed for installation of the tool
+func (tool Tool) Info() map[string]string {
+	var (
+		result = make(map[string]string)
+		item   = tool.readBuilds()[tool.Version]
+	)
+
+	result["filename"] = strings.TrimSuffix(item.Filename, ".zip")
+	result["extension"] = "zip"
+	result["url"] = item.URL
+	result["flat"] = "true"
+
+	if item.Sum != "" {
+		result["sha256"] = item.Sum
+	}
+
+	return result
+}
+
+// Bins returns list of the all bins included
+// with the distribution of the tool
+func (tool Tool) Bins() []string {
+	return []string{tool.Name}
+}
+
+// Dots returns list of the all available filenames
+// which can define versions
+func (tool Tool) Dots() []string {
+	return []string{"." + tool.Name + "-version"}
+}
+
+// ListRemote returns list of the all available remote versions
+func (tool Tool) ListRemote() (result []string, err error) {
+	body, err := request.Body(fmt.Sprintf("%s/%s/index.json", VersionLink, tool.Name))
+	if err != nil {
+		return
+	}
+
+	data := struct {
+		Versions map[string]release `json:"versions"`
+	}{}
+
+	err = json.Unmarshal([]byte(body), &data)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	builds := map[string]build{}
+
+	result = []string{}
+	for version, item := range data.Versions {
+		// Enterprise versions like "1.15.0+ent" are not for everyone
+		parsed, errParse := semver.Make(version)
+		if errParse != nil || len(parsed.Build) > 0 {
+			continue
+		}
+
+		// Not every version is available for every platform
+		archive, ok := findBuild(item.Builds)
+		if ok == false {
+			continue
+		}
+
+		archive.Shasums = item.Shasums
+		builds[version] = archive
+		result = append(result, version)
+	}
+
+	// Versions are listed as an object, so there is no order
+	result = versions.Sort(result)
+
+	err = tool.writeBuilds(builds)
+
+	return
+}
+
+// Resolve gets the newest version which satisfies the constraint,
+// installed versions are preferred to the ones known from the last ListRemote()
+func (tool Tool) Resolve(constraint string) (string, error) {
+	installed := io.ListVersions(variables.Prefix(tool.Name))
+
+	remote := []string{}
+	for version := range tool.readBuilds() {
+		remote = append(remote, version)
+	}
+
+	for _, list := range [][]string{installed, remote} {
+		for _, version := range versions.Sort(list) {
+			ok, err := Satisfies(version, constraint)
+			if err != nil {
+				return "", err
+			}
+
+			if ok {
+				return version, nil
+			}
+		}
+	}
+
+	return "", errors.New(fmt.Sprintf(
+		"There is no %s version which satisfies \"%s\"", tool.Name, constraint,
+	))
+}
+
+// Satisfies checks if version satisfies the constraint in the HashiCorp format,
+// like ">= 1.2.0, < 2.0.0". Prereleases match only the exact constraint
+func Satisfies(version, constraint string) (bool, error) {
+	parsed, err := semver.Make(version)
+	if err != nil {
+		return false, errors.New(err)
+	}
+
+	for _, part := range strings.Split(constraint, ",") {
+		part = strings.TrimSpace(part)
+
+		match := rConstraint.FindStringSubmatch(part)
+		if len(match) == 0 {
+			return false, errors.New("Incorrect constraint \"" + constraint + "\"")
+		}
+
+		operator, target := match[1], match[2]
+
+		expected, errParse := semver.ParseTolerant(target)
+		if errParse != nil {
+			return false, errors.New(errParse)
+		}
+
+		if len(parsed.Pre) > 0 && (operator == "" || operator == "=") == false {
+			return false, nil
+		}
+
+		if compare(parsed, expected, operator, target) == false {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func compare(version, expected semver.Version, operator, target string) bool {
+	switch operator {
+	case "", "=":
+		return version.EQ(expected)
+	case "!=":
+		return version.NE(expected)
+	case ">":
+		return version.GT(expected)
+	case ">=":
+		return version.GTE(expected)
+	case "<":
+		return version.LT(expected)
+	case "<=":
+		return version.LTE(expected)
+	}
+
+	// "~> 1.2" allows any 1.x starting from 1.2, "~> 1.2.3" – any 1.2.x starting from 1.2.3
+	upper := semver.Version{Major: expected.Major + 1}
+	if strings.Count(target, ".") == 2 {
+		upper = semver.Version{Major: expected.Major, Minor: expected.Minor + 1}
+	}
+
+	return version.GTE(expected) && version.LT(upper)
+}
+
+// getSum gets checksum of the archive from the SHA256SUMS of the version
+func (tool Tool) getSum(item build) (string, error) {
+	link := fmt.Sprintf("%s/%s/%s/%s", VersionLink, tool.Name, tool.Version, item.Shasums)
+
+	body, err := request.Body(link)
+	if err != nil {
+		return "", err
+	}
+
+	for _, line := range strings.Split(body, "\n") {
+		fields := strings.Fields(line)
+
+		if len(fields) == 2 && fields[1] == item.Filename {
+			return fields[0], nil
+		}
+	}
+
+	return "", errors.New("There is no checksum for \"" + item.Filename + "\"")
+}
+
+// findBuild finds the archive of the version for the platform
+func findBuild(builds []build) (build, bool) {
+	for _, item := range builds {
+		if item.OS == runtime.GOOS && item.Arch == runtime.GOARCH {
+			return item, true
+		}
+	}
+
+	return build{}, false
+}
+
+func (tool Tool) buildsPath() string {
+	return filepath.Join(variables.Cache(), tool.Name+"-builds")
+}
+
+// readBuilds gets version to archive map saved by the last ListRemote()
+func (tool Tool) readBuilds() map[string]build {
+	result := map[string]build{}
+
+	json.Unmarshal([]byte(io.Read(tool.buildsPath())), &result)
+
+	return result
+}
+
+func (tool Tool) writeBuilds(builds map[string]build) error {
+	content, err := json.Marshal(builds)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	_, err = io.CreateDir(variables.Cache())
+	if err != nil {
+		return err
+	}
+
+	return io.WriteFile(tool.buildsPath(), string(content))
+}
@@ -0,0 +1,13 @@
+package hashicorp_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestHashicorp(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Hashicorp Suite")
+}
@@ -0,0 +1,178 @@
+package hashicorp_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/markelog/monkey"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/markelog/eclectica/plugins/hashicorp"
+	"github.com/markelog/eclectica/variables"
+)
+
+var _ = Describe("hashicorp", func() {
+	var (
+		remotes []string
+		err     error
+		tmp     string
+		ts      *httptest.Server
+	)
+
+	tool := New("terraform", "", nil)
+	old := VersionLink
+
+	BeforeEach(func() {
+		tmp, _ = ioutil.TempDir("", "eclectica-hashicorp")
+
+		monkey.Patch(variables.Cache, func() string {
+			return tmp
+		})
+
+		monkey.Patch(variables.Prefix, func(name string) string {
+			return filepath.Join(tmp, name)
+		})
+
+		ts = httptest.NewServer(http.StripPrefix("/terraform", http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				http.ServeFile(w, r, filepath.Join("./testdata", filepath.Base(r.URL.Path)))
+			},
+		)))
+
+		VersionLink = ts.URL
+	})
+
+	AfterEach(func() {
+		VersionLink = old
+		ts.Close()
+
+		monkey.Unpatch(variables.Cache)
+		monkey.Unpatch(variables.Prefix)
+		os.RemoveAll(tmp)
+	})
+
+	Describe("ListRemote", func() {
+		BeforeEach(func() {
+			remotes, err = tool.ListRemote()
+		})
+
+		It("should not return an error", func() {
+			Expect(err).To(BeNil())
+		})
+
+		It("should list versions without enterprise ones", func() {
+			if runtime.GOARCH == "amd64" {
+				Expect(remotes).To(Equal([]string{"1.7.0-beta1", "1.6.6", "1.5.7", "0.11.15"}))
+			}
+
+			if runtime.GOARCH == "arm64" {
+				Expect(remotes).To(Equal([]string{"1.7.0-beta1", "1.6.6", "1.5.7"}))
+			}
+		})
+	})
+
+	Describe("Info", func() {
+		BeforeEach(func() {
+			tool.ListRemote()
+		})
+
+		It("should get info about 1.6.6 version", func() {
+			result := New("terraform", "1.6.6", nil).Info()
+
+			Expect(result["extension"]).To(Equal("zip"))
+			Expect(result["flat"]).To(Equal("true"))
+
+			if runtime.GOOS == "linux" && runtime.GOARCH == "amd64" {
+				Expect(result["filename"]).To(Equal("terraform_1.6.6_linux_amd64"))
+				Expect(result["url"]).To(Equal(
+					"https://releases.hashicorp.com/terraform/1.6.6/terraform_1.6.6_linux_amd64.zip",
+				))
+			}
+		})
+
+		It("should not have checksum before PreDownload", func() {
+			result := New("terraform", "1.6.6", nil).Info()
+
+			Expect(result).NotTo(HaveKey("sha256"))
+		})
+	})
+
+	Describe("PreDownload", func() {
+		It("should get checksum of the archive", func() {
+			terraform := New("terraform", "1.6.6", nil)
+
+			Expect(terraform.PreDownload()).To(BeNil())
+
+			if runtime.GOOS == "linux" && runtime.GOARCH == "amd64" {
+				Expect(terraform.Info()["sha256"]).To(Equal(
+					"b8a3892b58c33ee2b4b23e2ef4a3cd8cc2b1b0cff2aa4b7a8f2a7d17cf9eb6d8",
+				))
+			}
+		})
+
+		It("should return an error if there is no checksums", func() {
+			err := New("terraform", "1.5.7", nil).PreDownload()
+
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return an error for unknown version", func() {
+			err := New("terraform", "0.1.1", nil).PreDownload()
+
+			Expect(err.Error()).To(Equal("Incorrect version 0.1.1"))
+		})
+	})
+
+	Describe("Satisfies", func() {
+		It("should compare with operators", func() {
+			Expect(Satisfies("1.6.6", ">= 1.5.0, < 2.0.0")).To(Equal(true))
+			Expect(Satisfies("1.6.6", "!= 1.6.6")).To(Equal(false))
+			Expect(Satisfies("1.6.6", "1.6.6")).To(Equal(true))
+			Expect(Satisfies("1.6.6", "> 1.6")).To(Equal(true))
+		})
+
+		It("should support pessimistic operator", func() {
+			Expect(Satisfies("1.6.6", "~> 1.5")).To(Equal(true))
+			Expect(Satisfies("2.0.0", "~> 1.5")).To(Equal(false))
+			Expect(Satisfies("1.5.7", "~> 1.5.0")).To(Equal(true))
+			Expect(Satisfies("1.6.6", "~> 1.5.0")).To(Equal(false))
+		})
+
+		It("should match prereleases only exactly", func() {
+			Expect(Satisfies("1.7.0-beta1", ">= 1.5.0")).To(Equal(false))
+			Expect(Satisfies("1.7.0-beta1", "= 1.7.0-beta1")).To(Equal(true))
+		})
+
+		It("should return an error for incorrect constraint", func() {
+			_, err := Satisfies("1.6.6", "nope")
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Resolve", func() {
+		It("should prefer installed versions", func() {
+			tool.ListRemote()
+			os.MkdirAll(filepath.Join(tmp, "terraform", "1.5.7"), 0755)
+
+			Expect(tool.Resolve(">= 1.5")).To(Equal("1.5.7"))
+		})
+
+		It("should use remote versions if nothing is installed", func() {
+			tool.ListRemote()
+
+			Expect(tool.Resolve("~> 1.5")).To(Equal("1.6.6"))
+		})
+
+		It("should return an error if nothing satisfies", func() {
+			_, err := tool.Resolve(">= 2.0")
+
+			Expect(err.Error()).To(Equal("There is no terraform version which satisfies \">= 2.0\""))
+		})
+	})
+})
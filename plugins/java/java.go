@@ -0,0 +1,401 @@
+// Package java provides all needed logic for installation of Java.
+// Only Temurin is supported for now, since that's the only vendor
+// Adoptium API provides binaries of
+package java
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/chuckpreslar/emission"
+	"github.com/go-errors/errors"
+
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/pkg"
+	"github.com/markelog/eclectica/request"
+	"github.com/markelog/eclectica/variables"
+	"github.com/markelog/eclectica/versions"
+)
+
+var (
+	// VersionLink is the URL of the Adoptium compatible API from which we get all possible versions
+	VersionLink = "https://api.adoptium.net"
+
+	// DefaultVendor is used when version doesn't have one, like in "java@21"
+	DefaultVendor = "temurin"
+
+	// Vendors maps vendor names used in versions to the API ones
+	vendors = map[string]vendor{
+		"temurin": {
+			api: "eclipse",
+		},
+	}
+
+	// Vendors as they are named in sdkman identifiers, like "21.0.2-tem"
+	sdkmanVendors = map[string]string{
+		"tem": "temurin",
+	}
+
+	pageSize = 50
+
+	bins = []string{
+		"jar", "jarsigner", "java", "javac", "javadoc", "javap", "jcmd", "jdb",
+		"jdeps", "jlink", "jmod", "jps", "jshell", "jstack", "keytool",
+	}
+	dots = []string{".java-version", ".sdkmanrc"}
+
+	rDigit = regexp.MustCompile(`^\d`)
+)
+
+// Java essential struct
+type Java struct {
+	Version string
+	Emitter *emission.Emitter
+	pkg.Base
+}
+
+// vendor describes where to get JDKs of the vendor
+type vendor struct {
+	api string
+}
+
+// release is how API describes the version
+type release struct {
+	Major    int `json:"major"`
+	Minor    int `json:"minor"`
+	Security int `json:"security"`
+	Patch    int `json:"patch"`
+	Build    int `json:"build"`
+}
+
+// New returns language struct
+func New(version string, emitter *emission.Emitter) *Java {
+	return &Java{
+		Version: version,
+		Emitter: emitter,
+	}
+}
+
+// Events returns language related event emitter
+func (java Java) Events() *emission.Emitter {
+	return java.Emitter
+}
+
+// PreDownload hook
+func (java Java) PreDownload() error {
+	flavor, _ := versions.SplitFlavor(java.Version)
+	if _, ok := vendors[flavor]; ok == false {
+		return errors.New("Vendor \"" + flavor + "\" is not supported")
+	}
+
+	if _, ok := readReleases()[java.Version]; ok {
+		return nil
+	}
+
+	// Release names are known only after we get the list
+	_, err := java.ListRemote()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := readReleases()[java.Version]; ok == false {
+		return errors.New("Incorrect version " + java.Version)
+	}
+
+	return nil
+}
+
+// Install hook
+func (java Java) Install() (err error) {
+	var (
+		path = variables.Path("java", java.Version)
+		tmp  = path + "-bundle"
+		home = filepath.Join(tmp, "Contents", "Home")
+	)
+
+	// macOS archives are bundles with the JDK in "Contents/Home"
+	if _, errStat := os.Stat(filepath.Join(path, "Contents", "Home")); errStat != nil {
+		return
+	}
+
+	err = os.Rename(path, tmp)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	err = os.Rename(home, path)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	os.RemoveAll(tmp)
+
+	return
+}
+
+// Environment returns list of the all needed envionment variables
+func (java Java) Environment() (result []string, err error) {
+	result = append(result, "JAVA_HOME="+variables.Path("java", java.Version))
+
+	return
+}
+
+// Info provides all the info needed for installation of the plugin
+func (java Java) Info() map[string]string {
+	var (
+		result      = make(map[string]string)
+		name        = readReleases()[java.Version]
+		flavor, ver = versions.SplitFlavor(java.Version)
+		major       = strings.Split(ver, ".")[0]
+		platform    = getPlatform()
+	)
+
+	result["filename"] = fmt.Sprintf("OpenJDK%sU-jdk_%s_hotspot_%s", major, platform, fileVersion(name))
+	result["unarchive-filename"] = name
+
+	// API redirects to the archive of the vendor
+	result["url"] = fmt.Sprintf(
+		"%s/v3/binary/version/%s/%s/%s/jdk/hotspot/normal/%s",
+		VersionLink, strings.Replace(name, "+", "%2B", 1), getOS(), getArch(), vendors[flavor].api,
+	)
+
+	return result
+}
+
+// Bins returns list of the all bins included
+// with the distribution of the language
+func (java Java) Bins() []string {
+	return bins
+}
+
+// Dots returns list of the all available filenames
+// which can define versions
+func (java Java) Dots() []string {
+	return dots
+}
+
+// Normalize adds default vendor to the version if it doesn't have one
+func (java Java) Normalize(version string) (string, error) {
+	flavor, rest := versions.SplitFlavor(version)
+
+	if flavor != "" {
+		return version, nil
+	}
+
+	if rDigit.MatchString(rest) || rest == "latest" {
+		return versions.JoinFlavor(DefaultVendor, rest), nil
+	}
+
+	return version, nil
+}
+
+// ReadVersion gets version from the ".java-version" or ".sdkmanrc" files
+func (java Java) ReadVersion(path string) (string, error) {
+	content := strings.TrimSpace(io.Read(path))
+
+	if filepath.Base(path) != ".sdkmanrc" {
+		if content == "" {
+			return "", nil
+		}
+
+		return java.extract(strings.Split(content, "\n")[0])
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+
+		if strings.HasPrefix(line, "java=") == false {
+			continue
+		}
+
+		// Like "21.0.2-tem"
+		identifier := strings.TrimPrefix(line, "java=")
+		index := strings.LastIndex(identifier, "-")
+		if index == -1 {
+			return java.extract(identifier)
+		}
+
+		name := identifier[index+1:]
+		if full, ok := sdkmanVendors[name]; ok {
+			name = full
+		}
+
+		return java.extract(name + "-" + identifier[:index])
+	}
+
+	return "", nil
+}
+
+// extract version with the vendor from the string
+func (java Java) extract(value string) (string, error) {
+	flavor, rest := versions.SplitFlavor(strings.TrimSpace(value))
+
+	version, err := io.ExtractVersion(rest)
+	if err != nil {
+		return "", err
+	}
+
+	return java.Normalize(versions.JoinFlavor(flavor, version))
+}
+
+// ListRemote returns list of the all available remote versions
+func (java Java) ListRemote() (result []string, err error) {
+	names := map[string]string{}
+	result = []string{}
+
+	for _, name := range vendorNames() {
+		releases, errList := listReleases(vendors[name].api)
+		if errList != nil {
+			return nil, errList
+		}
+
+		for _, item := range releases {
+			version := fmt.Sprintf("%s-%d.%d.%d", name, item.Major, item.Minor, item.Security)
+
+			// Releases are sorted from the newest, so respins like 17.0.4.1 win
+			if _, ok := names[version]; ok {
+				continue
+			}
+
+			names[version] = releaseName(item)
+			result = append(result, version)
+		}
+	}
+
+	err = writeReleases(names)
+
+	return
+}
+
+// listReleases gets all GA releases of the vendor for this platform
+func listReleases(vendor string) (result []release, err error) {
+	osName, arch := getOS(), getArch()
+
+	for page := 0; ; page++ {
+		link := fmt.Sprintf(
+			"%s/v3/info/release_versions?release_type=ga&vendor=%s&image_type=jdk"+
+				"&jvm_impl=hotspot&os=%s&architecture=%s&sort_order=DESC&page_size=%d&page=%d",
+			VersionLink, vendor, osName, arch, pageSize, page,
+		)
+
+		body, errBody := request.Body(link)
+
+		// API responds with 404 when there are no more pages
+		if errBody != nil && page > 0 {
+			break
+		}
+
+		if errBody != nil {
+			return nil, errBody
+		}
+
+		data := struct {
+			Versions []release `json:"versions"`
+		}{}
+
+		err = json.Unmarshal([]byte(body), &data)
+		if err != nil {
+			return nil, errors.New(err)
+		}
+
+		result = append(result, data.Versions...)
+
+		if len(data.Versions) < pageSize {
+			break
+		}
+	}
+
+	return
+}
+
+// releaseName is how vendor names the release, like "jdk-21.0.2+13" or "jdk8u402-b06"
+func releaseName(item release) string {
+	if item.Major == 8 {
+		return fmt.Sprintf("jdk8u%d-b%02d", item.Security, item.Build)
+	}
+
+	version := fmt.Sprintf("%d", item.Major)
+
+	if item.Minor > 0 || item.Security > 0 || item.Patch > 0 {
+		version += fmt.Sprintf(".%d.%d", item.Minor, item.Security)
+	}
+
+	if item.Patch > 0 {
+		version += fmt.Sprintf(".%d", item.Patch)
+	}
+
+	return fmt.Sprintf("jdk-%s+%d", version, item.Build)
+}
+
+// fileVersion is how version is written in the archive name,
+// like "21.0.2_13" or "8u402b06"
+func fileVersion(name string) string {
+	if strings.HasPrefix(name, "jdk8u") {
+		return strings.Replace(strings.TrimPrefix(name, "jdk"), "-", "", 1)
+	}
+
+	return strings.Replace(strings.TrimPrefix(name, "jdk-"), "+", "_", 1)
+}
+
+func releasesPath() string {
+	return filepath.Join(variables.Cache(), "java-releases")
+}
+
+// readReleases gets version to release name map saved by the last ListRemote()
+func readReleases() map[string]string {
+	result := map[string]string{}
+
+	json.Unmarshal([]byte(io.Read(releasesPath())), &result)
+
+	return result
+}
+
+func writeReleases(names map[string]string) error {
+	content, err := json.Marshal(names)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	_, err = io.CreateDir(variables.Cache())
+	if err != nil {
+		return err
+	}
+
+	return io.WriteFile(releasesPath(), string(content))
+}
+
+func vendorNames() (result []string) {
+	for name := range vendors {
+		result = append(result, name)
+	}
+
+	sort.Strings(result)
+
+	return
+}
+
+func getOS() string {
+	if runtime.GOOS == "darwin" {
+		return "mac"
+	}
+
+	return runtime.GOOS
+}
+
+func getArch() string {
+	if runtime.GOARCH == "arm64" {
+		return "aarch64"
+	}
+
+	return "x64"
+}
+
+func getPlatform() string {
+	return getArch() + "_" + getOS()
+}
@@ -0,0 +1,13 @@
+package java_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestJava(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Java Suite")
+}
@@ -0,0 +1,176 @@
+package java_test
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/markelog/monkey"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	eIO "github.com/markelog/eclectica/io"
+	. "github.com/markelog/eclectica/plugins/java"
+	"github.com/markelog/eclectica/variables"
+)
+
+var _ = Describe("java", func() {
+	var (
+		remotes []string
+		err     error
+		tmp     string
+		ts      *httptest.Server
+	)
+
+	java := &Java{}
+	old := VersionLink
+
+	BeforeEach(func() {
+		tmp, _ = ioutil.TempDir("", "eclectica-java")
+
+		monkey.Patch(variables.Cache, func() string {
+			return tmp
+		})
+
+		content := eIO.Read("./testdata/release_versions.json")
+
+		ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("vendor") != "eclipse" {
+				w.WriteHeader(404)
+				return
+			}
+
+			io.WriteString(w, content)
+		}))
+
+		VersionLink = ts.URL
+	})
+
+	AfterEach(func() {
+		VersionLink = old
+		ts.Close()
+
+		monkey.Unpatch(variables.Cache)
+		os.RemoveAll(tmp)
+	})
+
+	Describe("ListRemote", func() {
+		BeforeEach(func() {
+			remotes, err = java.ListRemote()
+		})
+
+		It("should not return an error", func() {
+			Expect(err).To(BeNil())
+		})
+
+		It("should list versions with the vendor", func() {
+			Expect(remotes).To(Equal([]string{
+				"temurin-21.0.2", "temurin-21.0.0", "temurin-17.0.4", "temurin-8.0.402",
+			}))
+		})
+	})
+
+	Describe("Info", func() {
+		BeforeEach(func() {
+			java.ListRemote()
+		})
+
+		It("should get info about 21.0.2 version", func() {
+			result := (&Java{Version: "temurin-21.0.2"}).Info()
+
+			Expect(result["unarchive-filename"]).To(Equal("jdk-21.0.2+13"))
+
+			if runtime.GOOS == "linux" && runtime.GOARCH == "amd64" {
+				Expect(result["filename"]).To(Equal("OpenJDK21U-jdk_x64_linux_hotspot_21.0.2_13"))
+				Expect(result["url"]).To(Equal(
+					ts.URL + "/v3/binary/version/jdk-21.0.2%2B13/linux/x64/jdk/hotspot/normal/eclipse",
+				))
+			}
+		})
+
+		It("should use the latest respin", func() {
+			result := (&Java{Version: "temurin-17.0.4"}).Info()
+
+			Expect(result["unarchive-filename"]).To(Equal("jdk-17.0.4.1+1"))
+		})
+
+		It("should name first release of the major version without zeros", func() {
+			result := (&Java{Version: "temurin-21.0.0"}).Info()
+
+			Expect(result["unarchive-filename"]).To(Equal("jdk-21+35"))
+		})
+
+		It("should get info about 8 version", func() {
+			result := (&Java{Version: "temurin-8.0.402"}).Info()
+
+			Expect(result["unarchive-filename"]).To(Equal("jdk8u402-b06"))
+
+			if runtime.GOOS == "linux" && runtime.GOARCH == "amd64" {
+				Expect(result["filename"]).To(Equal("OpenJDK8U-jdk_x64_linux_hotspot_8u402b06"))
+			}
+		})
+	})
+
+	Describe("PreDownload", func() {
+		It("should get release names if they are not known", func() {
+			err := (&Java{Version: "temurin-21.0.2"}).PreDownload()
+
+			Expect(err).To(BeNil())
+			Expect((&Java{Version: "temurin-21.0.2"}).Info()["unarchive-filename"]).To(Equal("jdk-21.0.2+13"))
+		})
+
+		It("should return an error for unknown version", func() {
+			err := (&Java{Version: "temurin-21.0.99"}).PreDownload()
+
+			Expect(err).To(MatchError("Incorrect version temurin-21.0.99"))
+		})
+
+		It("should return an error for unsupported vendor", func() {
+			err := (&Java{Version: "zulu-21.0.2"}).PreDownload()
+
+			Expect(err).To(MatchError("Vendor \"zulu\" is not supported"))
+		})
+	})
+
+	Describe("Normalize", func() {
+		It("should add default vendor", func() {
+			Expect(java.Normalize("21")).To(Equal("temurin-21"))
+			Expect(java.Normalize("latest")).To(Equal("temurin-latest"))
+		})
+
+		It("should keep the vendor", func() {
+			Expect(java.Normalize("temurin-21")).To(Equal("temurin-21"))
+		})
+	})
+
+	Describe("ReadVersion", func() {
+		It("should read version from .java-version", func() {
+			path := filepath.Join(tmp, ".java-version")
+
+			eIO.WriteFile(path, "21\n")
+			Expect(java.ReadVersion(path)).To(Equal("temurin-21"))
+
+			eIO.WriteFile(path, "temurin-17.0.4\n")
+			Expect(java.ReadVersion(path)).To(Equal("temurin-17.0.4"))
+		})
+
+		It("should read version from .sdkmanrc", func() {
+			Expect(java.ReadVersion("./testdata/sdkman/.sdkmanrc")).To(Equal("temurin-21.0.2"))
+		})
+	})
+
+	Describe("Environment", func() {
+		It("should set JAVA_HOME", func() {
+			result, err := (&Java{Version: "temurin-21.0.2"}).Environment()
+
+			Expect(err).To(BeNil())
+			Expect(result).To(Equal([]string{
+				"JAVA_HOME=" + variables.Path("java", "temurin-21.0.2"),
+			}))
+		})
+	})
+})
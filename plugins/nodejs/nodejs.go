@@ -2,11 +2,14 @@
 package nodejs
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
+	"path/filepath"
 	"regexp"
 	"runtime"
 	"strings"
+	"sync"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/blang/semver"
@@ -15,19 +18,55 @@ import (
 
 	"github.com/markelog/eclectica/pkg"
 	"github.com/markelog/eclectica/plugins/nodejs/modules"
+	"github.com/markelog/eclectica/request"
 	"github.com/markelog/eclectica/variables"
+	"github.com/markelog/eclectica/versions"
 )
 
 var (
 	// VersionLink is the URL link from which we can get all possible versions
 	VersionLink = "https://nodejs.org/dist"
 
+	// DownloadLink is the URL of the release channels, like "nightly" or "rc"
+	DownloadLink = "https://nodejs.org/download"
+
+	// UnofficialLink is the URL of the builds for the less common platforms, like musl
+	UnofficialLink = "https://unofficial-builds.nodejs.org/download"
+
+	// MuslLoader is the path of the dynamic loader which exists only on musl systems
+	MuslLoader = "/lib/ld-musl-*"
+
+	// IsMusl checks if system uses musl instead of glibc, like Alpine does
+	IsMusl = isMusl
+
+	muslOnce sync.Once
+	musl     bool
+
 	versionPattern = "v\\d+\\.\\d+\\.\\d+$"
 
+	// Versions of the channels other than "release" are marked by them,
+	// like "21.0.0-rc.1" or "22.0.0-nightly20231201a1b2c3d4e5"
+	channels = map[string]*regexp.Regexp{
+		"rc":      regexp.MustCompile(`-rc\.\d+$`),
+		"nightly": regexp.MustCompile(`-nightly\d+[0-9a-f]*$`),
+	}
+
+	// Architectures as they are named in the archives
+	archs = map[string]string{
+		"amd64":   "x64",
+		"arm64":   "arm64",
+		"arm":     "armv7l",
+		"ppc64le": "ppc64le",
+		"s390x":   "s390x",
+	}
+
 	minimalVersion, _ = semver.Make("0.10.0")
 
-	bins = []string{"node", "npm", "npx"}
-	dots = []string{".nvmrc", ".node-version"}
+	bins = []string{"node", "npm", "npx", "corepack"}
+
+	// Packages which are shipped with node itself
+	bundled = []string{"npm", "corepack"}
+	dots    = []string{".nvmrc", ".node-version"}
 )
 
 // Node essential struct
@@ -92,23 +131,82 @@ func (node Node) Switch() (err error) {
 	return
 }
 
+// PreDownload hook
+func (node Node) PreDownload() error {
+	_, err := getPlatform()
+
+	return err
+}
+
 // Info provides all the info needed for installation of the plugin
 func (node Node) Info() map[string]string {
 	result := make(map[string]string)
-	sourcesURL := fmt.Sprintf("%s/v%s", VersionLink, node.Version)
+	sourcesURL := fmt.Sprintf("%s/v%s", getLink(node.Version), node.Version)
+	platform, _ := getPlatform()
 
-	result["filename"] = fmt.Sprintf("node-v%s-%s-x64", node.Version, runtime.GOOS)
+	result["filename"] = fmt.Sprintf("node-v%s-%s", node.Version, platform)
 	result["url"] = fmt.Sprintf("%s/%s.tar.gz", sourcesURL, result["filename"])
 
 	return result
 }
 
+// Normalize brings name of the channel to its latest version,
+// like "nightly" to "22.0.0-nightly20231201a1b2c3d4e5"
+func (node Node) Normalize(version string) (string, error) {
+	if _, ok := channels[version]; ok == false {
+		return version, nil
+	}
+
+	return latestOf(version)
+}
+
+// NormalizeInstalled brings name of the channel to its latest installed version
+func (node Node) NormalizeInstalled(version string, installed []string) string {
+	if _, ok := channels[version]; ok == false {
+		return version
+	}
+
+	for _, element := range versions.Sort(installed) {
+		if GetChannel(element) == version {
+			return element
+		}
+	}
+
+	return version
+}
+
 // Bins returns list of the all bins included
 // with the distribution of the language
 func (node Node) Bins() []string {
 	return bins
 }
 
+// IsPackage checks if executable was installed with "npm install -g"
+// and not shipped with node, since the former are linked to their packages
+func (node Node) IsPackage(path string) bool {
+	target, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return false
+	}
+
+	modules := filepath.Join("lib", "node_modules") + string(filepath.Separator)
+	parts := strings.SplitN(target, modules, 2)
+
+	// Not a package at all
+	if len(parts) == 1 {
+		return false
+	}
+
+	pkgName := strings.Split(parts[1], string(filepath.Separator))[0]
+	for _, name := range bundled {
+		if pkgName == name {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Dots returns list of the all available filenames
 // which can define versions
 func (node Node) Dots() []string {
@@ -153,3 +251,128 @@ func (node Node) ListRemote() ([]string, error) {
 
 	return result, nil
 }
+
+// GetChannel returns release channel of the version, like "rc" for "21.0.0-rc.1"
+func GetChannel(version string) string {
+	for channel, rChannel := range channels {
+		if rChannel.MatchString(version) {
+			return channel
+		}
+	}
+
+	return "release"
+}
+
+// isMusl looks for the musl loader, system doesn't change, so it's done only once
+func isMusl() bool {
+	muslOnce.Do(func() {
+		if runtime.GOOS != "linux" {
+			return
+		}
+
+		matches, _ := filepath.Glob(MuslLoader)
+		musl = len(matches) > 0
+	})
+
+	return musl
+}
+
+// getLink returns URL of the builds for the channel of the version,
+// musl builds are only provided by the unofficial builds project
+func getLink(version string) string {
+	channel := GetChannel(version)
+
+	if IsMusl() {
+		return fmt.Sprintf("%s/%s", UnofficialLink, channel)
+	}
+
+	if channel == "release" {
+		return VersionLink
+	}
+
+	return fmt.Sprintf("%s/%s", DownloadLink, channel)
+}
+
+// getPlatform returns platform as archives are named with it, like "linux-arm64"
+func getPlatform() (string, error) {
+	arch, ok := archs[runtime.GOARCH]
+	if ok == false {
+		return "", errors.New("Not supported architecture \"" + runtime.GOARCH + "\"")
+	}
+
+	platform := fmt.Sprintf("%s-%s", runtime.GOOS, arch)
+
+	if IsMusl() {
+		platform += "-musl"
+	}
+
+	return platform, nil
+}
+
+// latestOf gets the latest version of the channel
+func latestOf(channel string) (string, error) {
+	body, err := request.Body(fmt.Sprintf("%s/%s/index.json", DownloadLink, channel))
+	if err != nil {
+		return "", err
+	}
+
+	var releases []struct {
+		Version string `json:"version"`
+	}
+
+	err = json.Unmarshal([]byte(body), &releases)
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	// Newest version goes first
+	if len(releases) == 0 {
+		return "", errors.New("There are no versions in the " + channel + " channel")
+	}
+
+	return strings.Replace(releases[0].Version, "v", "", 1), nil
+}
+
+// Releases returns list of the all available remote versions with metadata
+func (node Node) Releases() (result []pkg.Release, err error) {
+	body, err := request.Body(VersionLink + "/index.json")
+	if err != nil {
+		if _, ok := err.(net.Error); ok {
+			return nil, errors.New(variables.ConnectionError)
+		}
+
+		return nil, err
+	}
+
+	var releases []struct {
+		Version string      `json:"version"`
+		Date    string      `json:"date"`
+		LTS     interface{} `json:"lts"`
+	}
+
+	err = json.Unmarshal([]byte(body), &releases)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	for _, release := range releases {
+		version := strings.Replace(release.Version, "v", "", 1)
+		parsed, errParse := semver.Make(version)
+
+		// Remove outdated versions
+		if errParse != nil || parsed.LT(minimalVersion) {
+			continue
+		}
+
+		// "lts" field is either `false` or the name of the release line
+		lts, _ := release.LTS.(string)
+
+		result = append(result, pkg.Release{
+			Version: version,
+			Date:    release.Date,
+			LTS:     lts,
+		})
+	}
+
+	return result, nil
+}
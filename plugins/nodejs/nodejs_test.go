@@ -12,6 +12,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	eio "github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/pkg"
 	. "github.com/markelog/eclectica/plugins/nodejs"
 	"github.com/markelog/eclectica/variables"
 )
@@ -90,10 +91,70 @@ var _ = Describe("nodejs", func() {
 		})
 	})
 
+	Describe("Releases", func() {
+		var (
+			releases []pkg.Release
+			old      = VersionLink
+		)
+
+		AfterEach(func() {
+			VersionLink = old
+		})
+
+		Describe("success", func() {
+			BeforeEach(func() {
+				content := eio.Read("../../testdata/plugins/nodejs/index.json")
+
+				ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					io.WriteString(w, content)
+				}))
+
+				VersionLink = ts.URL
+
+				releases, err = node.Releases()
+			})
+
+			It("should not return an error", func() {
+				Expect(err).To(BeNil())
+			})
+
+			It("should have release dates", func() {
+				Expect(releases[0].Version).To(Equal("21.0.0"))
+				Expect(releases[0].Date).To(Equal("2023-10-17"))
+			})
+
+			It("should have LTS names only for LTS releases", func() {
+				Expect(releases[0].LTS).To(Equal(""))
+				Expect(releases[1].LTS).To(Equal("Iron"))
+			})
+
+			It("should not contain outdated versions", func() {
+				Expect(releases).To(HaveLen(3))
+			})
+		})
+
+		Describe("fail", func() {
+			BeforeEach(func() {
+				VersionLink = ""
+				releases, err = node.Releases()
+			})
+
+			It("should return an error", func() {
+				Expect(err).Should(MatchError(variables.ConnectionError))
+			})
+		})
+	})
+
 	Describe("Info", func() {
+		oldIsMusl := IsMusl
+
 		BeforeEach(func() {
 			content := eio.Read("../../testdata/plugins/nodejs/latest.txt")
 
+			IsMusl = func() bool {
+				return false
+			}
+
 			httpmock.Activate()
 
 			httpmock.RegisterResponder(
@@ -110,6 +171,8 @@ var _ = Describe("nodejs", func() {
 		})
 
 		AfterEach(func() {
+			IsMusl = oldIsMusl
+
 			defer httpmock.DeactivateAndReset()
 		})
 
@@ -125,5 +188,100 @@ var _ = Describe("nodejs", func() {
 				Expect(result["url"]).To(Equal("https://nodejs.org/dist/v6.3.1/node-v6.3.1-linux-x64.tar.gz"))
 			}
 		})
+
+		It("should get info about version of the rc channel", func() {
+			result := (&Node{Version: "21.0.0-rc.1"}).Info()
+
+			if runtime.GOOS == "linux" && runtime.GOARCH == "amd64" {
+				Expect(result["filename"]).To(Equal("node-v21.0.0-rc.1-linux-x64"))
+				Expect(result["url"]).To(Equal(
+					"https://nodejs.org/download/rc/v21.0.0-rc.1/node-v21.0.0-rc.1-linux-x64.tar.gz",
+				))
+			}
+		})
+
+		It("should get info about musl build from the unofficial builds", func() {
+			IsMusl = func() bool {
+				return true
+			}
+
+			result := (&Node{Version: "20.9.0"}).Info()
+
+			if runtime.GOOS == "linux" && runtime.GOARCH == "amd64" {
+				Expect(result["filename"]).To(Equal("node-v20.9.0-linux-x64-musl"))
+				Expect(result["url"]).To(Equal(
+					"https://unofficial-builds.nodejs.org/download/release/" +
+						"v20.9.0/node-v20.9.0-linux-x64-musl.tar.gz",
+				))
+			}
+		})
+	})
+
+	Describe("GetChannel", func() {
+		It("should detect channels of the versions", func() {
+			Expect(GetChannel("21.0.0-rc.1")).To(Equal("rc"))
+			Expect(GetChannel("22.0.0-nightly20231201b0c1ec3a6e")).To(Equal("nightly"))
+			Expect(GetChannel("20.9.0")).To(Equal("release"))
+		})
+	})
+
+	Describe("Normalize", func() {
+		old := DownloadLink
+
+		BeforeEach(func() {
+			content := eio.Read("../../testdata/plugins/nodejs/nightly/index.json")
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/nightly/index.json" {
+					w.WriteHeader(404)
+					return
+				}
+
+				io.WriteString(w, content)
+			}))
+
+			DownloadLink = ts.URL
+		})
+
+		AfterEach(func() {
+			DownloadLink = old
+		})
+
+		It("should use the latest version of the channel", func() {
+			Expect(node.Normalize("nightly")).To(Equal("22.0.0-nightly20231201b0c1ec3a6e"))
+		})
+
+		It("should return an error if the channel can't be resolved", func() {
+			_, err := node.Normalize("rc")
+
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("should not touch versions", func() {
+			Expect(node.Normalize("20.9.0")).To(Equal("20.9.0"))
+		})
+	})
+
+	Describe("NormalizeInstalled", func() {
+		installed := []string{
+			"20.9.0",
+			"22.0.0-nightly20231130f1e2d3c4b5",
+			"22.0.0-nightly20231201b0c1ec3a6e",
+			"21.0.0-rc.1",
+		}
+
+		It("should use the latest installed version of the channel", func() {
+			Expect(node.NormalizeInstalled("nightly", installed)).To(
+				Equal("22.0.0-nightly20231201b0c1ec3a6e"),
+			)
+		})
+
+		It("should keep the channel if there is no installed version of it", func() {
+			Expect(node.NormalizeInstalled("rc", []string{"20.9.0"})).To(Equal("rc"))
+		})
+
+		It("should not touch versions", func() {
+			Expect(node.NormalizeInstalled("20.9.0", installed)).To(Equal("20.9.0"))
+		})
 	})
 })
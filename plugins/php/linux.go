@@ -0,0 +1,68 @@
+package php
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/mgutz/ansi"
+
+	"github.com/markelog/eclectica/cmd/print"
+)
+
+var (
+
+	// LinuxDependencies is a list of all linux system dependencies
+	LinuxDependencies = []string{
+		"make", "build-essential", "autoconf",
+		"bison", "re2c", "pkg-config",
+		"libxml2-dev", "libsqlite3-dev", "libssl-dev",
+		"zlib1g-dev", "libcurl4-openssl-dev", "libonig-dev",
+		"libreadline-dev",
+	}
+)
+
+func checkLinuxDependencies() (has bool, deps []string, err error) {
+	out, err := exec.Command("dpkg", "-l").Output()
+	if err != nil {
+		err = errors.New(err)
+		return
+	}
+
+	output := string(out)
+
+	for _, dep := range LinuxDependencies {
+		if strings.Contains(output, dep) == false {
+			deps = append(deps, dep)
+		}
+	}
+
+	if len(deps) > 0 {
+		has = true
+	}
+
+	return
+}
+
+func dealWithLinuxShell() error {
+	has, deps, err := checkLinuxDependencies()
+
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if has == false {
+		return nil
+	}
+
+	message := `PHP cannot be installed without external Linux dependencies,
+  please execute following command before trying it again (you need to do it only ` + ansi.Color("once", "red") + "):"
+	command := "sudo apt-get update && sudo apt-get install -y " + strings.Join(deps, " ")
+
+	print.Warning(message, command)
+	print.LastPrint()
+	os.Exit(1)
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package php
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/mgutz/ansi"
+
+	"github.com/markelog/eclectica/cmd/print"
+)
+
+var (
+
+	// OSXDependencies is a list of OSX system dependencies
+	OSXDependencies = []string{
+		"bison", "re2c", "pkg-config", "libxml2", "openssl", "oniguruma", "readline",
+	}
+
+	// XCodeDependencies is a list of XCode dependencies
+	XCodeDependencies = []string{
+		"xcrun", "make", "gcc",
+	}
+
+	// Brew doesn't link these, so "configure" wouldn't find them by itself
+	kegOnly = []string{"libxml2", "openssl", "readline"}
+)
+
+func checkXCodeDependencies() bool {
+	for _, dep := range XCodeDependencies {
+		_, err := exec.Command(dep, "--version").CombinedOutput()
+
+		if err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func checkOSXDependencies() (has bool, deps []string, err error) {
+	out, err := exec.Command("brew", "list").Output()
+	if err != nil {
+		err = errors.New(err)
+		return
+	}
+
+	output := string(out)
+
+	for _, dep := range OSXDependencies {
+		if strings.Contains(output, dep) == false {
+			deps = append(deps, dep)
+		}
+	}
+
+	if len(deps) > 0 {
+		has = true
+	}
+
+	return
+}
+
+func printErrForOSXDependencies(deps []string) {
+	message := `PHP cannot be installed without external dependencies,
+  please execute following command before trying it again (you need to do it only ` + ansi.Color("once", "red") + "):"
+	command := "brew update && brew install " + strings.Join(deps, " ")
+
+	print.Warning(message, command)
+	os.Exit(1)
+}
+
+func printErrForXCodeDependencies() {
+	message := `PHP cannot be installed without Xcode,
+	please download it from https://developer.apple.com/download/
+  before trying it again (you need to do it only ` + ansi.Color("once", "red") + "):"
+
+	print.Warning(message, "")
+	os.Exit(1)
+}
+
+func getOSXEnvs() (result []string) {
+	var (
+		pkgConfig = []string{}
+		paths     = []string{}
+	)
+
+	for _, name := range kegOnly {
+		out, err := exec.Command("brew", "--prefix", name).Output()
+		if err != nil {
+			continue
+		}
+
+		pkgConfig = append(pkgConfig, filepath.Join(strings.TrimSpace(string(out)), "lib", "pkgconfig"))
+	}
+
+	// Bison of the macOS is too old
+	out, err := exec.Command("brew", "--prefix", "bison").Output()
+	if err == nil {
+		paths = append(paths, filepath.Join(strings.TrimSpace(string(out)), "bin"))
+	}
+
+	paths = append(paths, os.Getenv("PATH"))
+
+	result = append(result, "PKG_CONFIG_PATH="+strings.Join(pkgConfig, ":"))
+	result = append(result, "PATH="+strings.Join(paths, ":"))
+
+	return
+}
+
+func dealWithOSXShell() error {
+	has := checkXCodeDependencies()
+
+	if has == false {
+		printErrForXCodeDependencies()
+		return nil
+	}
+
+	has, deps, err := checkOSXDependencies()
+
+	if err != nil {
+		return err
+	}
+
+	if has == false {
+		return nil
+	}
+
+	printErrForOSXDependencies(deps)
+
+	return nil
+}
@@ -0,0 +1,266 @@
+// Package php provides all needed logic for installation of PHP
+package php
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/chuckpreslar/emission"
+	"github.com/go-errors/errors"
+
+	"github.com/markelog/eclectica/compile"
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/pkg"
+	"github.com/markelog/eclectica/request"
+	"github.com/markelog/eclectica/variables"
+	"github.com/markelog/eclectica/versions"
+)
+
+var (
+	// VersionLink is the URL link from which we can get all possible versions
+	VersionLink = "https://www.php.net/releases/index.php"
+
+	// DownloadLink from which we download sources of the php
+	DownloadLink = "https://www.php.net/distributions"
+
+	// ConfigureOptions is the name of the environment variable
+	// with additional options for the "configure"
+	ConfigureOptions = "PHP_CONFIGURE_OPTIONS"
+
+	// Major versions which are listed
+	majors = []int{5, 7, 8}
+
+	// Older versions do not build on the modern systems
+	minimalVersion, _ = semver.Make("5.6.0")
+
+	// Options of the "configure" which are used by default
+	defaultOptions = []string{
+		"--enable-mbstring",
+		"--with-curl",
+		"--with-openssl",
+		"--with-pear",
+		"--with-readline",
+		"--with-zlib",
+	}
+
+	bins = []string{"php", "phpize", "php-config", "pecl"}
+	dots = []string{".php-version"}
+)
+
+// PHP essential struct
+type PHP struct {
+	Version string
+	Emitter *emission.Emitter
+	pkg.Base
+}
+
+// New returns language struct
+func New(version string, emitter *emission.Emitter) *PHP {
+	return &PHP{
+		Version: version,
+		Emitter: emitter,
+	}
+}
+
+// Events returns language related event emitter
+func (php PHP) Events() *emission.Emitter {
+	return php.Emitter
+}
+
+// PreInstall hook
+func (php PHP) PreInstall() (err error) {
+	var (
+		install = variables.InstallLanguage("php", php.Version)
+		current = variables.Path("php", php.Version)
+	)
+
+	// Just in case
+	os.RemoveAll(install)
+
+	_, err = io.CreateDir(filepath.Dir(install))
+	if err != nil {
+		return
+	}
+
+	err = os.Rename(current, install)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if runtime.GOOS == "linux" {
+		return dealWithLinuxShell()
+	}
+
+	if runtime.GOOS == "darwin" {
+		return dealWithOSXShell()
+	}
+
+	return
+}
+
+// Install hook
+func (php PHP) Install() (err error) {
+	source := compile.New(variables.InstallLanguage("php", php.Version), php.Emitter)
+
+	if runtime.GOOS == "darwin" {
+		source.Env = getOSXEnvs()
+	}
+
+	err = source.Configure(ConfigureArgs(php.Version)...)
+	if err != nil {
+		return
+	}
+
+	err = source.Prepare()
+	if err != nil {
+		return
+	}
+
+	err = source.Install()
+	if err != nil {
+		return
+	}
+
+	return php.setupIni()
+}
+
+// PostInstall hook
+func (php PHP) PostInstall() (err error) {
+	return os.RemoveAll(filepath.Join(variables.InstallPath(), "php"))
+}
+
+// Rollback hook
+func (php PHP) Rollback() (err error) {
+	return os.RemoveAll(filepath.Join(variables.InstallPath(), "php"))
+}
+
+// Info provides all the info needed for installation of the plugin
+func (php PHP) Info() map[string]string {
+	result := make(map[string]string)
+
+	result["filename"] = "php-" + php.Version
+	result["url"] = fmt.Sprintf("%s/%s.tar.gz", DownloadLink, result["filename"])
+
+	return result
+}
+
+// Bins returns list of the all bins included
+// with the distribution of the language
+func (php PHP) Bins() []string {
+	return bins
+}
+
+// IsBin checks if executable should be proxied,
+// there are others like "php-cgi" but they are not meant for the command line
+func (php PHP) IsBin(path string) bool {
+	name := filepath.Base(path)
+
+	for _, bin := range bins {
+		if bin == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Dots returns list of the all available filenames
+// which can define versions
+func (php PHP) Dots() []string {
+	return dots
+}
+
+// ListRemote returns list of the all available remote versions
+func (php PHP) ListRemote() (result []string, err error) {
+	result = []string{}
+
+	for _, major := range majors {
+		link := fmt.Sprintf("%s?json&max=1000&version=%d", VersionLink, major)
+
+		body, errBody := request.Body(link)
+		if errBody != nil {
+			return nil, errBody
+		}
+
+		releases := map[string]interface{}{}
+		err = json.Unmarshal([]byte(body), &releases)
+		if err != nil {
+			return nil, errors.New(err)
+		}
+
+		for version := range releases {
+			parsed, errParse := semver.Make(version)
+			if errParse != nil || parsed.LT(minimalVersion) {
+				continue
+			}
+
+			result = append(result, version)
+		}
+	}
+
+	// Releases are listed as an object, so there is no order
+	result = versions.Sort(result)
+
+	return
+}
+
+// ConfigureArgs gets arguments for the "configure" of the version,
+// php.ini and its extensions are looked up only in the folder of the version
+func ConfigureArgs(version string) []string {
+	var (
+		path   = variables.Path("php", version)
+		etc    = filepath.Join(path, "etc")
+		result = []string{
+			"--prefix=" + path,
+			"--with-config-file-path=" + etc,
+			"--with-config-file-scan-dir=" + filepath.Join(etc, "conf.d"),
+		}
+	)
+
+	result = append(result, defaultOptions...)
+
+	// Added last, so user could override defaults
+	return append(result, strings.Fields(os.Getenv(ConfigureOptions))...)
+}
+
+// setupIni creates php.ini of the version and points pecl to it,
+// so extensions installed with pecl would be enabled only for this version
+func (php PHP) setupIni() (err error) {
+	var (
+		path    = variables.Path("php", php.Version)
+		etc     = filepath.Join(path, "etc")
+		ini     = filepath.Join(etc, "php.ini")
+		example = filepath.Join(variables.InstallLanguage("php", php.Version), "php.ini-development")
+		pecl    = filepath.Join(path, "bin", "pecl")
+	)
+
+	_, err = io.CreateDir(filepath.Join(etc, "conf.d"))
+	if err != nil {
+		return
+	}
+
+	if _, errStat := os.Stat(ini); errStat != nil {
+		err = io.WriteFile(ini, io.Read(example))
+		if err != nil {
+			return
+		}
+	}
+
+	// There is no pecl if user disabled pear
+	if _, errStat := os.Stat(pecl); errStat != nil {
+		return
+	}
+
+	out, err := exec.Command(pecl, "config-set", "php_ini", ini, "system").CombinedOutput()
+	if err != nil {
+		return errors.New(string(out))
+	}
+
+	return
+}
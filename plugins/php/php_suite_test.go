@@ -0,0 +1,13 @@
+package php_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestPHP(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "PHP Suite")
+}
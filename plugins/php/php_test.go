@@ -0,0 +1,111 @@
+package php_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	eIO "github.com/markelog/eclectica/io"
+	. "github.com/markelog/eclectica/plugins/php"
+	"github.com/markelog/eclectica/variables"
+)
+
+var _ = Describe("php", func() {
+	var (
+		remotes []string
+		err     error
+	)
+
+	php := &PHP{}
+
+	Describe("ListRemote", func() {
+		old := VersionLink
+
+		AfterEach(func() {
+			VersionLink = old
+		})
+
+		Describe("success", func() {
+			BeforeEach(func() {
+				ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					major := r.URL.Query().Get("version")
+
+					io.WriteString(w, eIO.Read("./testdata/releases-"+major+".json"))
+				}))
+
+				VersionLink = ts.URL
+
+				remotes, err = php.ListRemote()
+			})
+
+			It("should not return an error", func() {
+				Expect(err).To(BeNil())
+			})
+
+			It("should have versions of every major", func() {
+				Expect(remotes).To(Equal([]string{"8.3.2", "8.2.15", "8.2.14", "7.4.33", "5.6.40"}))
+			})
+		})
+
+		Describe("fail", func() {
+			BeforeEach(func() {
+				ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(500)
+				}))
+
+				VersionLink = ts.URL
+
+				remotes, err = php.ListRemote()
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(MatchError(variables.ConnectionError))
+			})
+		})
+	})
+
+	Describe("Info", func() {
+		It("should get info about 8.2.15 version", func() {
+			result := (&PHP{Version: "8.2.15"}).Info()
+
+			Expect(result["filename"]).To(Equal("php-8.2.15"))
+			Expect(result["url"]).To(Equal("https://www.php.net/distributions/php-8.2.15.tar.gz"))
+		})
+	})
+
+	Describe("IsBin", func() {
+		It("should proxy command line tools", func() {
+			Expect(php.IsBin("/test/bin/php")).To(Equal(true))
+			Expect(php.IsBin("/test/bin/pecl")).To(Equal(true))
+		})
+
+		It("should not proxy the rest", func() {
+			Expect(php.IsBin("/test/bin/php-cgi")).To(Equal(false))
+		})
+	})
+
+	Describe("ConfigureArgs", func() {
+		AfterEach(func() {
+			os.Unsetenv(ConfigureOptions)
+		})
+
+		It("should look for php.ini in the version folder", func() {
+			etc := variables.Path("php", "8.2.15") + "/etc"
+
+			Expect(ConfigureArgs("8.2.15")).To(ContainElement("--with-config-file-path=" + etc))
+			Expect(ConfigureArgs("8.2.15")).To(ContainElement("--with-config-file-scan-dir=" + etc + "/conf.d"))
+		})
+
+		It("should add options from the environment at the end", func() {
+			os.Setenv(ConfigureOptions, "--with-gd  --without-pear")
+
+			args := ConfigureArgs("8.2.15")
+
+			Expect(args[len(args)-2:]).To(Equal([]string{"--with-gd", "--without-pear"}))
+		})
+	})
+})
@@ -2,18 +2,25 @@
 package plugins
 
 import (
+	"compress/gzip"
+	"crypto/sha256"
+	"crypto/sha512"
 	"fmt"
+	"hash"
+	goio "io"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strings"
 
 	"github.com/chuckpreslar/emission"
 	"github.com/go-errors/errors"
-	"github.com/kardianos/osext"
+	"github.com/ulikunitz/xz"
 	"gopkg.in/cavaliercoder/grab.v1"
 
 	"github.com/markelog/archive"
-	"github.com/markelog/cprf"
+	"github.com/markelog/archive/tar"
+	"github.com/markelog/eclectica/index"
 	"github.com/markelog/eclectica/io"
 	"github.com/markelog/eclectica/pkg"
 	"github.com/markelog/eclectica/shell"
@@ -21,12 +28,21 @@ import (
 	"github.com/markelog/eclectica/versions"
 
 	// plugins
+	"github.com/markelog/eclectica/plugins/bun"
+	"github.com/markelog/eclectica/plugins/deno"
+	"github.com/markelog/eclectica/plugins/dotnet"
+	"github.com/markelog/eclectica/plugins/elixir"
 	"github.com/markelog/eclectica/plugins/elm"
+	"github.com/markelog/eclectica/plugins/erlang"
 	"github.com/markelog/eclectica/plugins/golang"
+	"github.com/markelog/eclectica/plugins/java"
 	"github.com/markelog/eclectica/plugins/nodejs"
+	"github.com/markelog/eclectica/plugins/php"
 	"github.com/markelog/eclectica/plugins/python"
 	"github.com/markelog/eclectica/plugins/ruby"
 	"github.com/markelog/eclectica/plugins/rust"
+	"github.com/markelog/eclectica/plugins/terraform"
+	"github.com/markelog/eclectica/plugins/zig"
 )
 
 // Plugin essential struct
@@ -44,6 +60,9 @@ type Args struct {
 	Language    string
 	Version     string
 	WithModules bool
+	FromSource  bool
+	Components  []string
+	Targets     []string
 }
 
 var (
@@ -56,9 +75,27 @@ var (
 		"go",
 		"python",
 		"elm",
+		"deno",
+		"bun",
+		"java",
+		"zig",
+		"erlang",
+		"elixir",
+		"php",
+		"dotnet",
+		"terraform",
+	}
+
+	// Checksums which might be in the info of the plugin with their hashes
+	checksums = map[string]func() hash.Hash{
+		"sha256": sha256.New,
+		"sha512": sha512.New,
 	}
 )
 
+// System is a pseudo-version which points to the installation provided by the OS
+const System = "system"
+
 // New returns new plugin struct
 func New(args *Args) *Plugin {
 	plugin := &Plugin{
@@ -75,15 +112,42 @@ func New(args *Args) *Plugin {
 			WithModules: args.WithModules,
 		})
 	case args.Language == "rust":
-		plugin.Pkg = rust.New(args.Version, plugin.emitter)
+		plugin.Pkg = rust.New(&rust.Args{
+			Version:    args.Version,
+			Emitter:    plugin.emitter,
+			Components: args.Components,
+			Targets:    args.Targets,
+		})
 	case args.Language == "ruby":
 		plugin.Pkg = ruby.New(args.Version, plugin.emitter)
 	case args.Language == "go":
 		plugin.Pkg = golang.New(args.Version, plugin.emitter)
 	case args.Language == "python":
-		plugin.Pkg = python.New(args.Version, plugin.emitter)
+		plugin.Pkg = python.New(&python.Args{
+			Version:    args.Version,
+			Emitter:    plugin.emitter,
+			FromSource: args.FromSource,
+		})
 	case args.Language == "elm":
 		plugin.Pkg = elm.New(args.Version, plugin.emitter)
+	case args.Language == "deno":
+		plugin.Pkg = deno.New(args.Version, plugin.emitter)
+	case args.Language == "bun":
+		plugin.Pkg = bun.New(args.Version, plugin.emitter)
+	case args.Language == "java":
+		plugin.Pkg = java.New(args.Version, plugin.emitter)
+	case args.Language == "zig":
+		plugin.Pkg = zig.New(args.Version, plugin.emitter)
+	case args.Language == "erlang":
+		plugin.Pkg = erlang.New(args.Version, plugin.emitter)
+	case args.Language == "elixir":
+		plugin.Pkg = elixir.New(args.Version, plugin.emitter)
+	case args.Language == "php":
+		plugin.Pkg = php.New(args.Version, plugin.emitter)
+	case args.Language == "dotnet":
+		plugin.Pkg = dotnet.New(args.Version, plugin.emitter)
+	case args.Language == "terraform":
+		plugin.Pkg = terraform.New(args.Version, plugin.emitter)
 	}
 
 	if len(args.Version) > 0 {
@@ -99,8 +163,23 @@ func (plugin *Plugin) Events() *emission.Emitter {
 }
 
 // PreDownload executes logic before downloading of the plugin
-func (plugin *Plugin) PreDownload() error {
-	return plugin.Pkg.PreDownload()
+func (plugin *Plugin) PreDownload() (err error) {
+	if chooser, ok := plugin.Pkg.(pkg.Chooser); ok {
+		plugin.Pkg, err = chooser.Choose()
+		if err != nil {
+			return
+		}
+	}
+
+	err = plugin.Pkg.PreDownload()
+	if err != nil {
+		return
+	}
+
+	// Plugin might learn more about the version before the download
+	plugin.info, err = plugin.Info()
+
+	return
 }
 
 // PreInstall executes logic before installation of the plugin
@@ -131,11 +210,16 @@ func (plugin *Plugin) LocalInstall() (err error) {
 
 	// If this is already a current version we can safely say this one is installed
 	if plugin.Version == plugin.Current() {
-		return nil
+		return plugin.Extend()
 	}
 
 	// If it was already installed, just switch and bail out
 	if plugin.IsInstalled() {
+		err = plugin.Extend()
+		if err != nil {
+			return
+		}
+
 		return plugin.finishLocal()
 	}
 
@@ -156,6 +240,38 @@ func (plugin *Plugin) LocalInstall() (err error) {
 	return
 }
 
+// UseSystem makes proxies use language installation provided by the OS,
+// installed versions are left untouched
+func (plugin *Plugin) UseSystem() (err error) {
+	current := variables.Path(plugin.name)
+
+	if _, errStat := os.Lstat(current); os.IsNotExist(errStat) {
+		return nil
+	}
+
+	err = os.Remove(current)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	return
+}
+
+// LocalUseSystem same as UseSystem but only for the current folder
+func (plugin *Plugin) LocalUseSystem() (err error) {
+	pwd, err := os.Getwd()
+	if err != nil {
+		return errors.New(err)
+	}
+
+	var (
+		version = fmt.Sprintf(".%s-version", plugin.name)
+		path    = filepath.Join(pwd, version)
+	)
+
+	return io.WriteFile(path, System)
+}
+
 func (plugin Plugin) finishLocal() (err error) {
 	pwd, err := os.Getwd()
 	if err != nil {
@@ -207,12 +323,22 @@ func (plugin *Plugin) Install() (err error) {
 
 	// If this is already a current version we can safely say this one is installed
 	if plugin.Version == plugin.Current() {
+		err = plugin.Extend()
+		if err != nil {
+			return
+		}
+
 		init.Start()
 		return nil
 	}
 
 	// If it was already installed, just switch @current link if needed
 	if plugin.IsInstalled() {
+		err = plugin.Extend()
+		if err != nil {
+			return
+		}
+
 		err = plugin.finishInstall()
 		if err != nil {
 			return
@@ -278,6 +404,16 @@ func (plugin *Plugin) PostInstall() (err error) {
 	return
 }
 
+// Extend installs the parts requested for the version which is already installed
+func (plugin *Plugin) Extend() error {
+	extender, ok := plugin.Pkg.(pkg.Extender)
+	if ok == false {
+		return nil
+	}
+
+	return extender.Extend()
+}
+
 // Switch executes logic before switching plugin versions
 func (plugin *Plugin) Switch() (err error) {
 	err = plugin.Pkg.Switch()
@@ -467,6 +603,16 @@ func (plugin *Plugin) Extract() error {
 		return errors.New("version was not defined")
 	}
 
+	// Some plugins know the checksum of the archive
+	for name, fn := range checksums {
+		if sum, ok := plugin.info[name]; ok {
+			err := io.Verify(plugin.info["archive-path"], sum, fn())
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	// Create language folder with path like this – /home/user/.eclectica/versions/go
 	extractionPlace, err := io.CreateDir(variables.Prefix(plugin.name))
 	if err != nil {
@@ -491,7 +637,14 @@ func (plugin *Plugin) Extract() error {
 	// or like this – /home/user/.eclectica/versions/go/go
 	//
 	// Depends under what name language devs archived their dist
-	err = archive.Extract(plugin.info["archive-path"], extractionPlace)
+	//
+	// Some archives do not have the root folder at all, then it's created for them
+	place := extractionPlace
+	if plugin.info["flat"] == "true" {
+		place = filepath.Join(extractionPlace, plugin.info["unarchive-filename"])
+	}
+
+	err = extract(plugin.info["archive-path"], place)
 	if err != nil {
 		return err
 	}
@@ -515,10 +668,141 @@ func (plugin *Plugin) Extract() error {
 	return nil
 }
 
-// Bins returns list of the all bins included
-// with the distribution of the language
+// extract the archive, archive package doesn't know about xz
+// and gzipped files without tar, so we deal with them ourselves
+func extract(src, dest string) error {
+	if strings.HasSuffix(src, ".gz") && strings.HasSuffix(src, ".tar.gz") == false {
+		return gunzip(src, dest)
+	}
+
+	if strings.HasSuffix(src, ".tar.xz") == false {
+		return archive.Extract(src, dest)
+	}
+
+	file, err := os.Open(src)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	defer file.Close()
+
+	reader, err := xz.NewReader(file)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	return tar.Extract(reader, dest)
+}
+
+// gunzip decompresses single gzipped executable, like "elm.gz", to the dest folder
+func gunzip(src, dest string) error {
+	file, err := os.Open(src)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	defer file.Close()
+
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	defer reader.Close()
+
+	_, err = io.CreateDir(dest)
+	if err != nil {
+		return err
+	}
+
+	name := filepath.Join(dest, strings.TrimSuffix(filepath.Base(src), ".gz"))
+
+	result, err := os.OpenFile(name, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0755)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	defer result.Close()
+
+	_, err = goio.Copy(result, reader)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// Bins returns list of the all bins included with the distribution of the language,
+// if version is installed they are taken from its bin folder,
+// otherwise plugin gives its best guess
 func (plugin *Plugin) Bins() []string {
-	return plugin.Pkg.Bins()
+	var (
+		version = plugin.Version
+		result  = []string{}
+	)
+
+	if version == "" {
+		version = "current"
+	}
+
+	path := filepath.Join(variables.Path(plugin.name, version), "bin")
+	found := io.ListExecutables(path)
+
+	if len(found) == 0 {
+		return plugin.Pkg.Bins()
+	}
+
+	filter, hasFilter := plugin.Pkg.(pkg.BinFilter)
+	packages, hasPackages := plugin.Pkg.(pkg.PackageFilter)
+
+	for _, bin := range found {
+		binPath := filepath.Join(path, bin)
+
+		if hasFilter && filter.IsBin(binPath) == false {
+			continue
+		}
+
+		// Those are not shipped with the language
+		if hasPackages && packages.IsPackage(binPath) {
+			continue
+		}
+
+		result = append(result, bin)
+	}
+
+	return result
+}
+
+// Normalize brings version provided by the user to the form plugin lists its versions in
+func (plugin *Plugin) Normalize(version string) (string, error) {
+	normalizer, ok := plugin.Pkg.(pkg.Normalizer)
+	if ok == false {
+		return version, nil
+	}
+
+	return normalizer.Normalize(version)
+}
+
+// NormalizeInstalled brings version provided by the user to the installed one,
+// like "nightly" to the latest installed nightly instead of the latest remote one
+func (plugin *Plugin) NormalizeInstalled(version string) (string, error) {
+	normalizer, ok := plugin.Pkg.(pkg.InstalledNormalizer)
+	if ok == false {
+		return plugin.Normalize(version)
+	}
+
+	return normalizer.NormalizeInstalled(version, plugin.List()), nil
+}
+
+// LocalVersion finds version defined by the dot files for the folder,
+// "current" is returned if there is none
+func (plugin *Plugin) LocalVersion(dir string) (version, path string, err error) {
+	reader, ok := plugin.Pkg.(pkg.VersionReader)
+	if ok == false {
+		return io.GetVersion(plugin.Dots(), dir)
+	}
+
+	return io.FindVersion(plugin.Dots(), dir, reader.ReadVersion)
 }
 
 // Dots returns list of the all available filenames
@@ -537,7 +821,7 @@ func (plugin *Plugin) List() (vers []string) {
 
 // ListRemote returns list of the all available remote versions
 func (plugin *Plugin) ListRemote() (map[string][]string, error) {
-	vers, err := plugin.Pkg.ListRemote()
+	vers, err := plugin.FullListRemote()
 
 	if err != nil {
 		return nil, err
@@ -546,6 +830,46 @@ func (plugin *Plugin) ListRemote() (map[string][]string, error) {
 	return versions.Compose(vers), nil
 }
 
+// FullListRemote returns flat list of the all available remote versions
+// and caches it, so it could be used without network later on
+func (plugin *Plugin) FullListRemote() (vers []string, err error) {
+	vers, err = plugin.Pkg.ListRemote()
+	if err != nil {
+		return
+	}
+
+	// Cache is nice to have, so ignore the errors
+	plugin.cacheRemote(vers)
+
+	return
+}
+
+// CachedListRemote returns list of the remote versions of the language
+// received from the last successful request, plugin is not needed for it
+func CachedListRemote(language string) (vers []string) {
+	vers = []string{}
+	content := strings.TrimSpace(io.Read(cachePath(language)))
+
+	if content == "" {
+		return
+	}
+
+	return strings.Split(content, "\n")
+}
+
+func (plugin *Plugin) cacheRemote(vers []string) (err error) {
+	_, err = io.CreateDir(variables.Cache())
+	if err != nil {
+		return
+	}
+
+	return io.WriteFile(cachePath(plugin.name), strings.Join(vers, "\n"))
+}
+
+func cachePath(language string) string {
+	return filepath.Join(variables.Cache(), language+"-remote")
+}
+
 // Link replaces (if needed) and sets symlink for the language
 func (plugin *Plugin) Link() (err error) {
 	var (
@@ -571,65 +895,13 @@ func (plugin *Plugin) IsInstalled() bool {
 	return variables.IsInstalled(plugin.name, plugin.Version)
 }
 
-// Proxy installs the proxy for the language
-func (plugin *Plugin) Proxy() (err error) {
-	ecProxyFolder := os.Getenv("EC_PROXY_PLACE")
-
-	if ecProxyFolder == "" {
-		ecProxyFolder, err = osext.ExecutableFolder()
-		if err != nil {
-			return
-		}
-	}
-
-	executable := filepath.Join(ecProxyFolder, "ec-proxy")
-
-	_, err = os.Stat(executable)
-	if err != nil {
-		if os.IsNotExist(err) {
-			err = errors.New("Can't find ec-proxy binary")
-		}
-
-		return err
-	}
-
-	bins := plugin.Bins()
-
-	for _, bin := range bins {
-		err = cprf.Copy(executable, variables.DefaultInstall)
-		if err != nil {
-			return
-		}
-
-		fullProxy := filepath.Join(variables.DefaultInstall, "ec-proxy")
-		fullBin := filepath.Join(variables.DefaultInstall, bin)
-
-		err = os.Rename(fullProxy, fullBin)
-		if err != nil {
-			return
-		}
-	}
-
-	return nil
-}
-
-func (plugin *Plugin) removeProxy() (err error) {
-	bins := plugin.Bins()
-
-	for _, bin := range bins {
-		proxy := filepath.Join(variables.DefaultInstall, bin)
-
-		err = os.RemoveAll(proxy)
-		if err != nil {
-			return
-		}
-	}
-
-	return nil
-}
-
 // SearchBin searches for the actual binary
 func SearchBin(name string) string {
+	// Proxies created by eclectica are always in the index
+	if language := index.Language(name); language != "" {
+		return language
+	}
+
 	bins := map[string][]string{}
 
 	for _, language := range Plugins {
@@ -3,6 +3,7 @@ package plugins_test
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -19,8 +20,10 @@ import (
 	. "github.com/markelog/eclectica/plugins"
 	"github.com/markelog/eclectica/shell"
 
+	"github.com/markelog/eclectica/index"
 	eIO "github.com/markelog/eclectica/io"
 	"github.com/markelog/eclectica/plugins/nodejs"
+	"github.com/markelog/eclectica/plugins/rust"
 	"github.com/markelog/eclectica/variables"
 )
 
@@ -52,6 +55,187 @@ var _ = Describe("plugins", func() {
 		})
 	})
 
+	Describe("Bins", func() {
+		var tmp string
+
+		BeforeEach(func() {
+			tmp, _ = ioutil.TempDir("", "eclectica-bins")
+
+			monkey.Patch(variables.Home, func() string {
+				return tmp
+			})
+		})
+
+		AfterEach(func() {
+			monkey.Unpatch(variables.Home)
+			os.RemoveAll(tmp)
+		})
+
+		It("takes bins from the bin folder of the installed version", func() {
+			bin := filepath.Join(tmp, "python", "3.12.0", "bin")
+			os.MkdirAll(bin, 0755)
+			ioutil.WriteFile(filepath.Join(bin, "python3.12"), []byte(""), 0755)
+			ioutil.WriteFile(filepath.Join(bin, "pip3.12"), []byte(""), 0755)
+
+			bins := New(&Args{
+				Language: "python",
+				Version:  "3.12.0",
+			}).Bins()
+
+			Expect(bins).To(ConsistOf("python3.12", "pip3.12"))
+		})
+
+		It("falls back to the plugin list if version is not installed", func() {
+			bins := New(&Args{
+				Language: "python",
+				Version:  "3.12.0",
+			}).Bins()
+
+			Expect(bins).To(ContainElement("python3"))
+		})
+
+		It("filters out bins which plugin doesn't consider its own", func() {
+			bin := filepath.Join(tmp, "node", "20.0.0", "bin")
+			modules := filepath.Join(tmp, "node", "20.0.0", "lib", "node_modules")
+
+			os.MkdirAll(bin, 0755)
+			os.MkdirAll(filepath.Join(modules, "npm", "bin"), 0755)
+			os.MkdirAll(filepath.Join(modules, "typescript", "bin"), 0755)
+
+			ioutil.WriteFile(filepath.Join(bin, "node"), []byte(""), 0755)
+			ioutil.WriteFile(filepath.Join(modules, "npm", "bin", "npm-cli.js"), []byte(""), 0755)
+			ioutil.WriteFile(filepath.Join(modules, "typescript", "bin", "tsc"), []byte(""), 0755)
+
+			os.Symlink("../lib/node_modules/npm/bin/npm-cli.js", filepath.Join(bin, "npm"))
+			os.Symlink("../lib/node_modules/typescript/bin/tsc", filepath.Join(bin, "tsc"))
+
+			bins := New(&Args{
+				Language: "node",
+				Version:  "20.0.0",
+			}).Bins()
+
+			Expect(bins).To(ConsistOf("node", "npm"))
+		})
+
+		It("keeps executables installed by package managers for the rehash", func() {
+			bin := filepath.Join(tmp, "node", "20.0.0", "bin")
+			modules := filepath.Join(tmp, "node", "20.0.0", "lib", "node_modules")
+
+			os.MkdirAll(bin, 0755)
+			os.MkdirAll(filepath.Join(modules, "typescript", "bin"), 0755)
+
+			ioutil.WriteFile(filepath.Join(bin, "node"), []byte(""), 0755)
+			ioutil.WriteFile(filepath.Join(modules, "typescript", "bin", "tsc"), []byte(""), 0755)
+
+			os.Symlink("../lib/node_modules/typescript/bin/tsc", filepath.Join(bin, "tsc"))
+
+			executables := New(&Args{
+				Language: "node",
+			}).Executables()
+
+			Expect(executables).To(ConsistOf("node", "tsc"))
+		})
+
+		It("filters out executables which plugin doesn't proxy for the rehash", func() {
+			bin := filepath.Join(tmp, "php", "8.3.0", "bin")
+
+			os.MkdirAll(bin, 0755)
+			ioutil.WriteFile(filepath.Join(bin, "php"), []byte(""), 0755)
+			ioutil.WriteFile(filepath.Join(bin, "php-cgi"), []byte(""), 0755)
+
+			executables := New(&Args{
+				Language: "php",
+			}).Executables()
+
+			Expect(executables).To(ConsistOf("php"))
+		})
+	})
+
+	Describe("IsProxyOutdated", func() {
+		var (
+			tmp    string
+			source string
+		)
+
+		BeforeEach(func() {
+			tmp, _ = ioutil.TempDir("", "eclectica-rehash")
+			source = filepath.Join(tmp, "place", "ec-proxy")
+
+			os.MkdirAll(filepath.Dir(source), 0755)
+			ioutil.WriteFile(source, []byte("proxy"), 0755)
+			os.Setenv("EC_PROXY_PLACE", filepath.Dir(source))
+
+			monkey.Patch(variables.Base, func() string {
+				return tmp
+			})
+		})
+
+		AfterEach(func() {
+			monkey.Unpatch(variables.Base)
+			os.Unsetenv("EC_PROXY_PLACE")
+			os.RemoveAll(tmp)
+		})
+
+		It("is outdated until proxies are rehashed", func() {
+			Expect(IsProxyOutdated()).To(Equal(true))
+			Expect(Rehash()).To(BeNil())
+			Expect(IsProxyOutdated()).To(Equal(false))
+		})
+
+		It("is outdated again after update of eclectica", func() {
+			Rehash()
+
+			ioutil.WriteFile(source, []byte("newer proxy"), 0755)
+
+			Expect(IsProxyOutdated()).To(Equal(true))
+		})
+	})
+
+	Describe("UseSystem", func() {
+		var tmp string
+
+		BeforeEach(func() {
+			tmp, _ = ioutil.TempDir("", "eclectica-system")
+
+			monkey.Patch(variables.Home, func() string {
+				return tmp
+			})
+		})
+
+		AfterEach(func() {
+			monkey.Unpatch(variables.Home)
+			os.RemoveAll(tmp)
+		})
+
+		It("removes current version link but keeps the version", func() {
+			version := filepath.Join(tmp, "node", "6.4.0")
+			current := filepath.Join(tmp, "node", "current")
+
+			os.MkdirAll(version, 0755)
+			os.Symlink(version, current)
+
+			err := New(&Args{
+				Language: "node",
+			}).UseSystem()
+
+			Expect(err).To(BeNil())
+
+			_, errCurrent := os.Lstat(current)
+			Expect(os.IsNotExist(errCurrent)).To(Equal(true))
+
+			_, errVersion := os.Stat(version)
+			Expect(errVersion).To(BeNil())
+		})
+
+		It("does nothing if there is no current version", func() {
+			err := New(&Args{
+				Language: "node",
+			}).UseSystem()
+
+			Expect(err).To(BeNil())
+		})
+	})
+
 	Describe("Remove", func() {
 		var (
 			list        = false
@@ -154,6 +338,61 @@ var _ = Describe("plugins", func() {
 		})
 	})
 
+	Describe("Remove proxies", func() {
+		var (
+			tmp            string
+			defaultInstall = variables.DefaultInstall
+		)
+
+		BeforeEach(func() {
+			tmp, _ = ioutil.TempDir("", "eclectica-remove")
+
+			monkey.Patch(variables.Home, func() string {
+				return filepath.Join(tmp, "versions")
+			})
+
+			variables.DefaultInstall = filepath.Join(tmp, "bin")
+			monkey.Patch(index.Path, func() string {
+				return filepath.Join(tmp, "index")
+			})
+
+			bin := filepath.Join(tmp, "versions", "python", "3.12.0", "bin")
+			os.MkdirAll(bin, 0755)
+			os.MkdirAll(variables.DefaultInstall, 0755)
+
+			ioutil.WriteFile(filepath.Join(bin, "python3.12"), []byte(""), 0755)
+			os.Symlink("ec-proxy", filepath.Join(variables.DefaultInstall, "python3.12"))
+			os.Symlink("ec-proxy", filepath.Join(variables.DefaultInstall, "node"))
+
+			index.Add("python", []string{"python3.12"})
+			index.Add("node", []string{"node"})
+		})
+
+		AfterEach(func() {
+			monkey.Unpatch(variables.Home)
+			monkey.Unpatch(index.Path)
+
+			variables.DefaultInstall = defaultInstall
+
+			os.RemoveAll(tmp)
+		})
+
+		It("removes proxies of the discovered bins with the last version", func() {
+			err := New(&Args{
+				Language: "python",
+				Version:  "3.12.0",
+			}).Remove()
+
+			Expect(err).To(BeNil())
+
+			_, err = os.Lstat(filepath.Join(variables.DefaultInstall, "python3.12"))
+			Expect(os.IsNotExist(err)).To(Equal(true))
+
+			Expect(index.Language("python3.12")).To(Equal(""))
+			Expect(index.Language("node")).To(Equal("node"))
+		})
+	})
+
 	Describe("Install", func() {
 		var (
 			pluginSwitch = false
@@ -189,6 +428,8 @@ var _ = Describe("plugins", func() {
 		resIsInstalled = false
 
 		var (
+			isMusl = nodejs.IsMusl
+
 			guardPluginSwitch *monkey.PatchGuard
 			guardCurrent      *monkey.PatchGuard
 			guardPostInstall  *monkey.PatchGuard
@@ -276,6 +517,11 @@ var _ = Describe("plugins", func() {
 			monkey.Patch(eIO.WriteFile, func(path, content string) error {
 				return nil
 			})
+
+			// Looking for the musl loader would use patched os.Stat
+			nodejs.IsMusl = func() bool {
+				return false
+			}
 		})
 
 		AfterEach(func() {
@@ -308,6 +554,8 @@ var _ = Describe("plugins", func() {
 			guardCurrent.Unpatch()
 			guardPkgInstall.Unpatch()
 			guardIsInstalled.Unpatch()
+
+			nodejs.IsMusl = isMusl
 		})
 
 		It("install sequence for not installed version", func() {
@@ -390,6 +638,56 @@ var _ = Describe("plugins", func() {
 			Expect(pkgInstall).To(Equal(true))
 		})
 
+		Describe("requested parts of the installed version", func() {
+			var (
+				extended bool
+				guard    *monkey.PatchGuard
+			)
+
+			BeforeEach(func() {
+				var r rust.Rust
+
+				extended = false
+
+				guard = monkey.PatchInstanceMethod(reflect.TypeOf(r), "Extend",
+					func(rust.Rust) error {
+						extended = true
+						return nil
+					},
+				)
+			})
+
+			AfterEach(func() {
+				guard.Unpatch()
+			})
+
+			It("installs them for the current version", func() {
+				resCurrent = "1.75.0"
+
+				New(&Args{
+					Language:   "rust",
+					Version:    "1.75.0",
+					Components: []string{"clippy"},
+				}).Install()
+
+				Expect(extended).To(Equal(true))
+				Expect(postInstall).To(Equal(false))
+			})
+
+			It("installs them for the installed version", func() {
+				resIsInstalled = true
+
+				New(&Args{
+					Language:   "rust",
+					Version:    "1.75.0",
+					Components: []string{"clippy"},
+				}).Install()
+
+				Expect(extended).To(Equal(true))
+				Expect(postInstall).To(Equal(false))
+			})
+		})
+
 		It("returns error if version was not defined", func() {
 			plugin := New(&Args{
 				Language: "node",
@@ -470,6 +768,62 @@ var _ = Describe("plugins", func() {
 			_, err = os.Stat(failedAttempt)
 			Expect(err).ShouldNot(BeNil())
 		})
+
+		It("should extract xz archive", func() {
+			info["archive-path"] = filepath.Join(path, filename+".tar.xz")
+
+			Expect(plugin.Extract()).To(BeNil())
+
+			_, err := os.Stat(filepath.Join(destFolder, "/test.txt"))
+			Expect(err).To(BeNil())
+		})
+
+		It("should extract archive without the root folder", func() {
+			info["archive-path"] = filepath.Join(path, "node-flat.zip")
+			info["flat"] = "true"
+
+			Expect(plugin.Extract()).To(BeNil())
+
+			_, err := os.Stat(filepath.Join(destFolder, "/test.txt"))
+			Expect(err).To(BeNil())
+		})
+
+		It("should extract gzipped executable", func() {
+			info["archive-path"] = filepath.Join(path, "node-binary.gz")
+			info["flat"] = "true"
+
+			Expect(plugin.Extract()).To(BeNil())
+
+			stat, err := os.Stat(filepath.Join(destFolder, "node-binary"))
+			Expect(err).To(BeNil())
+			Expect(stat.Mode() & 0111).NotTo(BeZero())
+		})
+
+		It("should extract if checksum matches", func() {
+			info["archive-path"] = filepath.Join(path, filename+".tar.xz")
+			info["sha256"] = "008aecb722dccd9654e9c6666c057becaa36e80773e38b1f1ed0b52414f7bc62"
+
+			Expect(plugin.Extract()).To(BeNil())
+		})
+
+		It("should verify sha512 checksum too", func() {
+			info["archive-path"] = filepath.Join(path, filename+".tar.xz")
+			info["sha512"] = "5667194ab36bebce3c02e1e615efb5cab13ea1a17ba6a348a6bb9ebf75a1ef94" +
+				"c18e5b50a1cb9fa11af34850da92df6eab881ba25be37ec152dd74d5af600191"
+
+			Expect(plugin.Extract()).To(BeNil())
+		})
+
+		It("should return an error if checksum doesn't match", func() {
+			info["sha256"] = "008aecb722dccd9654e9c6666c057becaa36e80773e38b1f1ed0b52414f7bc62"
+
+			err := plugin.Extract()
+
+			Expect(err.Error()).To(Equal("Checksum mismatch for \"node-arch.tar.gz\""))
+
+			_, err = os.Stat(destFolder)
+			Expect(err).ShouldNot(BeNil())
+		})
 	})
 
 	Describe("Download", func() {
@@ -0,0 +1,325 @@
+package plugins
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/kardianos/osext"
+	"github.com/markelog/cprf"
+
+	"github.com/markelog/eclectica/index"
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/pkg"
+	"github.com/markelog/eclectica/variables"
+)
+
+// Proxy installs the proxy for the language,
+// every proxy is a symlink to the single ec-proxy copy
+func (plugin *Plugin) Proxy() (err error) {
+	_, err = UpdateProxy()
+	if err != nil {
+		return
+	}
+
+	return plugin.link(plugin.Bins())
+}
+
+// Rehash creates proxies for every executable in bin folders of all the
+// installed versions of the language, i.e. for the ones installed by package managers,
+// and removes proxies for the executables which are gone
+func (plugin *Plugin) Rehash() (err error) {
+	var (
+		bins  = plugin.Bins()
+		known = index.Read()
+		stale = []string{}
+	)
+
+	for _, bin := range plugin.Executables() {
+
+		// First come, first served
+		if language, ok := known[bin]; ok && language != plugin.name {
+			continue
+		}
+
+		if contains(bins, bin) == false {
+			bins = append(bins, bin)
+		}
+	}
+
+	for bin, language := range known {
+		if language == plugin.name && contains(bins, bin) == false {
+			stale = append(stale, bin)
+		}
+	}
+
+	for _, bin := range stale {
+		err = os.RemoveAll(filepath.Join(variables.DefaultInstall, bin))
+		if err != nil {
+			return errors.New(err)
+		}
+	}
+
+	err = index.Remove(stale)
+	if err != nil {
+		return
+	}
+
+	return plugin.link(bins)
+}
+
+// Executables returns names of the all executables in bin folders
+// of the all installed versions of the language
+func (plugin *Plugin) Executables() (result []string) {
+	result = []string{}
+	filter, hasFilter := plugin.Pkg.(pkg.BinFilter)
+
+	for _, version := range plugin.List() {
+		bin := filepath.Join(variables.Path(plugin.name, version), "bin")
+
+		for _, name := range io.ListExecutables(bin) {
+			if hasFilter && filter.IsBin(filepath.Join(bin, name)) == false {
+				continue
+			}
+
+			if contains(result, name) == false {
+				result = append(result, name)
+			}
+		}
+	}
+
+	return
+}
+
+// link creates proxies for provided bins
+func (plugin *Plugin) link(bins []string) (err error) {
+	master := variables.Proxy()
+
+	for _, bin := range bins {
+		proxy := filepath.Join(variables.DefaultInstall, bin)
+
+		if isProxy(proxy) {
+			continue
+		}
+
+		err = io.Symlink(proxy, master)
+		if err != nil {
+			return
+		}
+	}
+
+	// So ec-proxy wouldn't need to ask every plugin about its bins
+	return index.Add(plugin.name, bins)
+}
+
+// removeProxy removes proxies of the language, including the ones found in
+// bin folders of the versions, which might not be there anymore, so index knows them
+func (plugin *Plugin) removeProxy() (err error) {
+	bins := plugin.Bins()
+
+	for bin, language := range index.Read() {
+		if language == plugin.name && contains(bins, bin) == false {
+			bins = append(bins, bin)
+		}
+	}
+
+	for _, bin := range bins {
+		proxy := filepath.Join(variables.DefaultInstall, bin)
+
+		err = os.RemoveAll(proxy)
+		if err != nil {
+			return
+		}
+	}
+
+	return index.Remove(bins)
+}
+
+// UpdateProxy copies ec-proxy binary which is shipped with eclectica
+// to the eclectica support folder if that copy is missing or outdated
+func UpdateProxy() (updated bool, err error) {
+	source, err := proxySource()
+	if err != nil {
+		return
+	}
+
+	stamp, err := proxyStamp(source)
+	if err != nil {
+		return
+	}
+
+	if isProxyFresh(stamp) {
+		return false, nil
+	}
+
+	var (
+		master = variables.Proxy()
+		dir    = filepath.Dir(master)
+		tmp    = filepath.Join(dir, "ec-proxy.tmp")
+	)
+
+	_, err = io.CreateDir(dir)
+	if err != nil {
+		return
+	}
+
+	os.RemoveAll(tmp)
+	_, err = io.CreateDir(tmp)
+	if err != nil {
+		return
+	}
+
+	err = cprf.Copy(source, tmp)
+	if err != nil {
+		os.RemoveAll(tmp)
+		return false, errors.New(err)
+	}
+
+	// Rename is atomic, so already running proxies wouldn't notice a thing
+	err = os.Rename(filepath.Join(tmp, "ec-proxy"), master)
+	os.RemoveAll(tmp)
+	if err != nil {
+		return false, errors.New(err)
+	}
+
+	err = io.WriteFile(variables.ProxyStamp(), stamp)
+	if err != nil {
+		return
+	}
+
+	return true, nil
+}
+
+// IsProxyOutdated checks if proxies were not rehashed since eclectica was updated,
+// proxies of older eclectica versions are copies, not links, so they need it too
+func IsProxyOutdated() bool {
+	stamp, err := sourceStamp()
+	if err != nil {
+		return false
+	}
+
+	return io.Read(variables.RehashStamp()) != stamp
+}
+
+// Rehash updates ec-proxy copy and recreates proxies for all installed languages,
+// including proxies for the executables installed by package managers
+func Rehash() (err error) {
+	stamp, err := sourceStamp()
+	if err != nil {
+		return
+	}
+
+	_, err = UpdateProxy()
+	if err != nil {
+		return
+	}
+
+	failed := []string{}
+
+	for _, language := range Plugins {
+
+		// Check it beforehand, since some plugins are not cheap to create
+		if len(io.ListVersions(variables.Prefix(language))) == 0 {
+			continue
+		}
+
+		// One broken language shouldn't leave proxies of others behind
+		errRehash := New(&Args{
+			Language: language,
+		}).Rehash()
+		if errRehash != nil {
+			failed = append(failed, language+" ("+errRehash.Error()+")")
+		}
+	}
+
+	// Don't try again until the next update, even if some of the languages failed
+	err = io.WriteFile(variables.RehashStamp(), stamp)
+	if err != nil {
+		return
+	}
+
+	if len(failed) > 0 {
+		return errors.New("Can't rehash proxies for " + strings.Join(failed, ", "))
+	}
+
+	return
+}
+
+// proxySource gets path to the ec-proxy binary shipped with eclectica
+func proxySource() (string, error) {
+	ecProxyFolder := os.Getenv("EC_PROXY_PLACE")
+
+	if ecProxyFolder == "" {
+		folder, err := osext.ExecutableFolder()
+		if err != nil {
+			return "", errors.New(err)
+		}
+
+		ecProxyFolder = folder
+	}
+
+	executable := filepath.Join(ecProxyFolder, "ec-proxy")
+
+	// Package managers like to install binaries as links
+	executable, err := filepath.EvalSymlinks(executable)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", errors.New("Can't find ec-proxy binary")
+		}
+
+		return "", errors.New(err)
+	}
+
+	return executable, nil
+}
+
+// proxyStamp gets stamp of the ec-proxy binary, which changes with every upgrade
+func proxyStamp(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	return fmt.Sprintf("%d %d", info.Size(), info.ModTime().UnixNano()), nil
+}
+
+// sourceStamp gets the stamp of the ec-proxy binary shipped with eclectica
+func sourceStamp() (string, error) {
+	source, err := proxySource()
+	if err != nil {
+		return "", err
+	}
+
+	return proxyStamp(source)
+}
+
+// isProxyFresh checks if ec-proxy copy was made from the binary with provided stamp
+func isProxyFresh(stamp string) bool {
+	if _, err := os.Stat(variables.Proxy()); err != nil {
+		return false
+	}
+
+	return io.Read(variables.ProxyStamp()) == stamp
+}
+
+// isProxy checks if provided path is already a link to the ec-proxy copy
+func isProxy(path string) bool {
+	target, err := os.Readlink(path)
+	if err != nil {
+		return false
+	}
+
+	return target == variables.Proxy()
+}
+
+func contains(list []string, element string) bool {
+	for _, item := range list {
+		if item == element {
+			return true
+		}
+	}
+
+	return false
+}
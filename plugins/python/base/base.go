@@ -0,0 +1,125 @@
+// Package base provides the logic shared by the implementations of Python
+// which are installed from the prebuilt archives, like PyPy
+package base
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/chuckpreslar/emission"
+	"github.com/go-errors/errors"
+
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/pkg"
+	"github.com/markelog/eclectica/variables"
+	"github.com/markelog/eclectica/versions"
+)
+
+var (
+	bins = []string{"python", "python3", "pip", "pip3"}
+	dots = []string{".python-version"}
+
+	// Implementations without the version, like "pypy3.10" or "graalpy"
+	rImplementation = regexp.MustCompile(`^(pypy\d+\.\d+|graalpy)$`)
+
+	// Shared libraries are placed next to the binaries by some implementations
+	rLibrary = regexp.MustCompile(`\.(so|dylib)$`)
+)
+
+// Python is base struct for the rest of the Python plugin related structs
+type Python struct {
+	Version string
+	Emitter *emission.Emitter
+	pkg.Base
+}
+
+// PostInstall hook
+func (python Python) PostInstall() (err error) {
+	bin := variables.GetBin("python", python.Version)
+
+	out, err := exec.Command(bin, "-m", "ensurepip").CombinedOutput()
+	if err != nil {
+		return errors.New(string(out))
+	}
+
+	return
+}
+
+// Bins returns list of the all bins included
+// with the distribution of the language
+func (python Python) Bins() []string {
+	return bins
+}
+
+// IsBin checks if executable should be proxied
+func (python Python) IsBin(path string) bool {
+	return rLibrary.MatchString(path) == false
+}
+
+// Dots returns list of the all available filenames
+// which can define versions
+func (python Python) Dots() []string {
+	return dots
+}
+
+// Normalize brings implementation without the version to its latest one,
+// like "pypy3.10" to "pypy3.10-latest"
+func (python Python) Normalize(version string) (string, error) {
+	if rImplementation.MatchString(version) {
+		return versions.JoinFlavor(version, "latest"), nil
+	}
+
+	return version, nil
+}
+
+// ReadVersion gets version from the ".python-version" file, which might
+// have the implementation prefix or variant, like "pypy3.10-7.3.15" or "3.13t"
+func (python Python) ReadVersion(path string) (string, error) {
+	content := strings.TrimSpace(io.Read(path))
+	if content == "" {
+		return "", nil
+	}
+
+	version := strings.TrimSpace(strings.Split(content, "\n")[0])
+
+	// pyenv names GraalPy with its edition
+	version = strings.Replace(version, "graalpy-community-", "graalpy-", 1)
+
+	flavor, rest := versions.SplitFlavor(version)
+	rest, variant := versions.SplitVariant(rest)
+
+	extracted, err := io.ExtractVersion(rest)
+	if err != nil {
+		return "", err
+	}
+
+	return versions.JoinFlavor(flavor, versions.JoinVariant(extracted, variant)), nil
+}
+
+// Link creates links for the names in the bin folder of the version
+// if they don't exist, so "pypy3" could be executed as "python"
+func Link(version, target string, names ...string) error {
+	bin := filepath.Join(variables.Path("python", version), "bin")
+
+	if _, err := os.Stat(filepath.Join(bin, target)); err != nil {
+		return errors.New(err)
+	}
+
+	for _, name := range names {
+		path := filepath.Join(bin, name)
+
+		if _, err := os.Lstat(path); err == nil {
+			continue
+		}
+
+		err := os.Symlink(target, path)
+		if err != nil {
+			return errors.New(err)
+		}
+	}
+
+	return nil
+}
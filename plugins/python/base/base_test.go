@@ -0,0 +1,53 @@
+package base_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/markelog/eclectica/plugins/python/base"
+)
+
+var _ = Describe("base", func() {
+	python := Python{}
+
+	Describe("ReadVersion", func() {
+		It("should read CPython version", func() {
+			Expect(python.ReadVersion("./testdata/cpython/.python-version")).To(Equal("3.12.1"))
+		})
+
+		It("should keep the implementation prefix", func() {
+			Expect(python.ReadVersion("./testdata/pypy/.python-version")).To(Equal("pypy3.10-7.3.15"))
+		})
+
+		It("should drop the edition of GraalPy", func() {
+			Expect(python.ReadVersion("./testdata/graalpy/.python-version")).To(Equal("graalpy-23.1.2"))
+		})
+
+		It("should keep the variant", func() {
+			Expect(python.ReadVersion("./testdata/variant/.python-version")).To(Equal("3.13t"))
+		})
+
+		It("should return nothing for empty file", func() {
+			Expect(python.ReadVersion("./testdata/empty/.python-version")).To(Equal(""))
+		})
+	})
+
+	Describe("Normalize", func() {
+		It("should use the latest version of the implementation", func() {
+			Expect(python.Normalize("pypy3.10")).To(Equal("pypy3.10-latest"))
+			Expect(python.Normalize("graalpy")).To(Equal("graalpy-latest"))
+		})
+
+		It("should not touch other versions", func() {
+			Expect(python.Normalize("3.12")).To(Equal("3.12"))
+			Expect(python.Normalize("pypy3.10-7.3")).To(Equal("pypy3.10-7.3"))
+		})
+	})
+
+	Describe("IsBin", func() {
+		It("should not proxy shared libraries", func() {
+			Expect(python.IsBin("/bin/libpypy3.10-c.so")).To(Equal(false))
+			Expect(python.IsBin("/bin/pypy3")).To(Equal(true))
+		})
+	})
+})
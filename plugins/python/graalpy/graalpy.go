@@ -0,0 +1,109 @@
+// Package graalpy provides all needed logic for installation of GraalPy
+package graalpy
+
+import (
+	"fmt"
+	"regexp"
+	"runtime"
+
+	"github.com/blang/semver"
+	"github.com/chuckpreslar/emission"
+
+	"github.com/markelog/eclectica/plugins/python/base"
+	"github.com/markelog/eclectica/request"
+	"github.com/markelog/eclectica/versions"
+)
+
+var (
+	// VersionLink is the URL link from which we can get all possible versions
+	VersionLink = "https://api.github.com/repos/oracle/graalpython/tags"
+
+	// DownloadLink from which we download binaries for graalpy
+	DownloadLink = "https://github.com/oracle/graalpython/releases/download"
+
+	// Community archives are named consistently only from this version
+	minimalVersion, _ = semver.Make("23.1.0")
+
+	rVersion = regexp.MustCompile(`^graal-(\d+\.\d+\.\d+)$`)
+)
+
+// GraalPy essential struct
+type GraalPy struct {
+	base.Python
+}
+
+// New returns language struct
+func New(version string, emitter *emission.Emitter) *GraalPy {
+	return &GraalPy{
+		Python: base.Python{
+			Version: version,
+			Emitter: emitter,
+		},
+	}
+}
+
+// Events returns language related event emitter
+func (graalpy GraalPy) Events() *emission.Emitter {
+	return graalpy.Emitter
+}
+
+// Install hook
+func (graalpy GraalPy) Install() error {
+	return base.Link(graalpy.Version, "graalpy", "python", "python3")
+}
+
+// Info provides all the info needed for installation of the plugin
+func (graalpy GraalPy) Info() map[string]string {
+	var (
+		result  = make(map[string]string)
+		_, rest = versions.SplitFlavor(graalpy.Version)
+	)
+
+	result["filename"] = fmt.Sprintf("graalpy-community-%s-%s", rest, getPlatform())
+	result["url"] = fmt.Sprintf("%s/graal-%s/%s.tar.gz", DownloadLink, rest, result["filename"])
+
+	return result
+}
+
+// ListRemote returns list of the all available remote versions, like "graalpy-24.0.0"
+func (graalpy GraalPy) ListRemote() (result []string, err error) {
+	tags, err := request.GitHubTags(VersionLink)
+	if err != nil {
+		return
+	}
+
+	result = []string{}
+	for _, tag := range tags {
+		match := rVersion.FindStringSubmatch(tag)
+		if len(match) == 0 {
+			continue
+		}
+
+		version, errParse := semver.Make(match[1])
+		if errParse != nil || version.LT(minimalVersion) {
+			continue
+		}
+
+		result = append(result, versions.JoinFlavor("graalpy", match[1]))
+	}
+
+	return
+}
+
+// getPlatform returns platform as archives are named with it, like "linux-amd64"
+func getPlatform() string {
+	var (
+		osName = runtime.GOOS
+		arch   = "amd64"
+	)
+
+	if osName == "darwin" {
+		osName = "macos"
+	}
+
+	if runtime.GOARCH == "arm64" {
+		arch = "aarch64"
+	}
+
+	return osName + "-" + arch
+}
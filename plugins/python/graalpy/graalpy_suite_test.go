@@ -0,0 +1,13 @@
+package graalpy_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestGraalPy(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "GraalPy Suite")
+}
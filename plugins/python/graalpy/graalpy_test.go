@@ -0,0 +1,67 @@
+package graalpy_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	eIO "github.com/markelog/eclectica/io"
+	. "github.com/markelog/eclectica/plugins/python/graalpy"
+)
+
+var _ = Describe("graalpy", func() {
+	var (
+		remotes []string
+		err     error
+	)
+
+	graalpy := New("", nil)
+
+	Describe("ListRemote", func() {
+		old := VersionLink
+
+		BeforeEach(func() {
+			content := eIO.Read("./testdata/tags.json")
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				io.WriteString(w, content)
+			}))
+
+			VersionLink = ts.URL
+
+			remotes, err = graalpy.ListRemote()
+		})
+
+		AfterEach(func() {
+			VersionLink = old
+		})
+
+		It("should not return an error", func() {
+			Expect(err).To(BeNil())
+		})
+
+		It("should list versions with the implementation prefix", func() {
+			Expect(remotes).To(Equal([]string{
+				"graalpy-24.0.0", "graalpy-23.1.2", "graalpy-23.1.0",
+			}))
+		})
+	})
+
+	Describe("Info", func() {
+		It("should get info about graalpy-24.0.0 version", func() {
+			result := New("graalpy-24.0.0", nil).Info()
+
+			if runtime.GOOS == "linux" && runtime.GOARCH == "amd64" {
+				Expect(result["filename"]).To(Equal("graalpy-community-24.0.0-linux-amd64"))
+				Expect(result["url"]).To(Equal(
+					"https://github.com/oracle/graalpython/releases/download/" +
+						"graal-24.0.0/graalpy-community-24.0.0-linux-amd64.tar.gz",
+				))
+			}
+		})
+	})
+})
@@ -85,7 +85,7 @@ func printErrForXCodeDependencies() {
 }
 
 func isWithOpenSSLFlag(version string) bool {
-	madeVersion, _ := semver.Make(version)
+	madeVersion, _ := semver.Make(sourceVersion(version))
 
 	return madeVersion.GTE(minimalForWithOpenSSLFlag)
 }
@@ -0,0 +1,168 @@
+// Package pypy provides all needed logic for installation of PyPy
+package pypy
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/chuckpreslar/emission"
+	"github.com/go-errors/errors"
+
+	"github.com/markelog/eclectica/plugins/python/base"
+	"github.com/markelog/eclectica/request"
+	"github.com/markelog/eclectica/versions"
+)
+
+var (
+	// VersionLink is the URL link from which we can get all possible versions
+	VersionLink = "https://downloads.python.org/pypy/versions.json"
+
+	// DownloadLink from which we download binaries for pypy
+	DownloadLink = "https://downloads.python.org/pypy"
+
+	// Archives for the arm macOS are available only from this version
+	minimalVersion, _ = semver.Make("7.3.10")
+
+	// Architectures as they are named in the versions list
+	archs = map[string]string{
+		"amd64": "x64",
+		"arm64": "aarch64",
+	}
+)
+
+// PyPy essential struct
+type PyPy struct {
+	base.Python
+}
+
+// release is how versions list describes the version
+type release struct {
+	Version string `json:"pypy_version"`
+	Python  string `json:"python_version"`
+	Stable  bool   `json:"stable"`
+	Files   []file `json:"files"`
+}
+
+// file is how versions list describes the archive of the version
+type file struct {
+	Arch     string `json:"arch"`
+	Platform string `json:"platform"`
+}
+
+// New returns language struct
+func New(version string, emitter *emission.Emitter) *PyPy {
+	return &PyPy{
+		Python: base.Python{
+			Version: version,
+			Emitter: emitter,
+		},
+	}
+}
+
+// Events returns language related event emitter
+func (pypy PyPy) Events() *emission.Emitter {
+	return pypy.Emitter
+}
+
+// Install hook
+func (pypy PyPy) Install() error {
+	var (
+		flavor, _ = versions.SplitFlavor(pypy.Version)
+		major     = strings.Split(strings.TrimPrefix(flavor, "pypy"), ".")[0]
+	)
+
+	return base.Link(pypy.Version, "pypy"+major, "python", "python"+major)
+}
+
+// Info provides all the info needed for installation of the plugin
+func (pypy PyPy) Info() map[string]string {
+	var (
+		result       = make(map[string]string)
+		flavor, rest = versions.SplitFlavor(pypy.Version)
+	)
+
+	result["filename"] = fmt.Sprintf("%s-v%s-%s", flavor, rest, getPlatform())
+	result["extension"] = "tar.bz2"
+	result["url"] = fmt.Sprintf("%s/%s.%s", DownloadLink, result["filename"], result["extension"])
+
+	return result
+}
+
+// ListRemote returns list of the all available remote versions,
+// named as pyenv does it, like "pypy3.10-7.3.15"
+func (pypy PyPy) ListRemote() (result []string, err error) {
+	body, err := request.Body(VersionLink)
+	if err != nil {
+		return
+	}
+
+	releases := []release{}
+	err = json.Unmarshal([]byte(body), &releases)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	result = []string{}
+	for _, item := range releases {
+		// There are nightly builds in the list too
+		version, errParse := semver.Make(item.Version)
+		if errParse != nil || item.Stable == false || version.LT(minimalVersion) {
+			continue
+		}
+
+		if hasPlatform(item.Files) == false {
+			continue
+		}
+
+		python := strings.Split(item.Python, ".")
+		if len(python) < 2 {
+			continue
+		}
+
+		flavor := "pypy" + python[0] + "." + python[1]
+		result = append(result, versions.JoinFlavor(flavor, item.Version))
+	}
+
+	return
+}
+
+// hasPlatform checks if there is an archive for the platform
+func hasPlatform(files []file) bool {
+	for _, item := range files {
+		if item.Platform == runtime.GOOS && item.Arch == getArch() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getArch returns architecture as versions list names it
+func getArch() string {
+	// arm macOS is named differently from the arm linux
+	if runtime.GOOS == "darwin" && runtime.GOARCH == "arm64" {
+		return "arm64"
+	}
+
+	return archs[runtime.GOARCH]
+}
+
+// getPlatform returns platform as archives are named with it, like "linux64"
+func getPlatform() string {
+	if runtime.GOOS == "darwin" {
+		if runtime.GOARCH == "arm64" {
+			return "macos_arm64"
+		}
+
+		return "macos_x86_64"
+	}
+
+	if runtime.GOARCH == "arm64" {
+		return "aarch64"
+	}
+
+	return "linux64"
+}
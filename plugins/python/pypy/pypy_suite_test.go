@@ -0,0 +1,13 @@
+package pypy_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestPyPy(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "PyPy Suite")
+}
@@ -0,0 +1,119 @@
+package pypy_test
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/markelog/monkey"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	eIO "github.com/markelog/eclectica/io"
+	. "github.com/markelog/eclectica/plugins/python/pypy"
+	"github.com/markelog/eclectica/variables"
+)
+
+var _ = Describe("pypy", func() {
+	var (
+		remotes []string
+		err     error
+	)
+
+	pypy := New("", nil)
+
+	Describe("ListRemote", func() {
+		old := VersionLink
+
+		BeforeEach(func() {
+			content := eIO.Read("./testdata/versions.json")
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				io.WriteString(w, content)
+			}))
+
+			VersionLink = ts.URL
+
+			remotes, err = pypy.ListRemote()
+		})
+
+		AfterEach(func() {
+			VersionLink = old
+		})
+
+		It("should not return an error", func() {
+			Expect(err).To(BeNil())
+		})
+
+		It("should list stable versions with the version of python", func() {
+			Expect(remotes).To(Equal([]string{
+				"pypy3.10-7.3.15", "pypy3.9-7.3.15", "pypy2.7-7.3.15", "pypy3.10-7.3.14",
+			}))
+		})
+	})
+
+	Describe("Info", func() {
+		It("should get info about pypy3.10-7.3.15 version", func() {
+			result := New("pypy3.10-7.3.15", nil).Info()
+
+			Expect(result["extension"]).To(Equal("tar.bz2"))
+
+			if runtime.GOOS == "linux" && runtime.GOARCH == "amd64" {
+				Expect(result["filename"]).To(Equal("pypy3.10-v7.3.15-linux64"))
+				Expect(result["url"]).To(Equal(
+					"https://downloads.python.org/pypy/pypy3.10-v7.3.15-linux64.tar.bz2",
+				))
+			}
+		})
+	})
+
+	Describe("Install", func() {
+		var tmp string
+
+		BeforeEach(func() {
+			tmp, _ = ioutil.TempDir("", "eclectica-pypy")
+
+			monkey.Patch(variables.Home, func() string {
+				return tmp
+			})
+		})
+
+		AfterEach(func() {
+			monkey.Unpatch(variables.Home)
+			os.RemoveAll(tmp)
+		})
+
+		It("should link pypy3 as python", func() {
+			bin := filepath.Join(tmp, "python", "pypy3.10-7.3.15", "bin")
+
+			os.MkdirAll(bin, 0755)
+			ioutil.WriteFile(filepath.Join(bin, "pypy3"), []byte(""), 0755)
+
+			Expect(New("pypy3.10-7.3.15", nil).Install()).To(BeNil())
+
+			Expect(os.Readlink(filepath.Join(bin, "python"))).To(Equal("pypy3"))
+			Expect(os.Readlink(filepath.Join(bin, "python3"))).To(Equal("pypy3"))
+		})
+
+		It("should link pypy2.7 version of pypy", func() {
+			bin := filepath.Join(tmp, "python", "pypy2.7-7.3.15", "bin")
+
+			os.MkdirAll(bin, 0755)
+			ioutil.WriteFile(filepath.Join(bin, "pypy2"), []byte(""), 0755)
+
+			Expect(New("pypy2.7-7.3.15", nil).Install()).To(BeNil())
+
+			Expect(os.Readlink(filepath.Join(bin, "python2"))).To(Equal("pypy2"))
+		})
+
+		It("should return an error if there is no pypy", func() {
+			os.MkdirAll(filepath.Join(tmp, "python", "pypy3.10-7.3.15", "bin"), 0755)
+
+			Expect(New("pypy3.10-7.3.15", nil).Install()).To(HaveOccurred())
+		})
+	})
+})
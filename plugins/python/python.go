@@ -25,7 +25,11 @@ import (
 
 	"github.com/markelog/eclectica/console"
 	"github.com/markelog/eclectica/pkg"
+	"github.com/markelog/eclectica/plugins/python/base"
+	"github.com/markelog/eclectica/plugins/python/graalpy"
 	"github.com/markelog/eclectica/plugins/python/patch"
+	"github.com/markelog/eclectica/plugins/python/pypy"
+	"github.com/markelog/eclectica/plugins/python/standalone"
 	eStrings "github.com/markelog/eclectica/strings"
 	"github.com/markelog/eclectica/variables"
 	"github.com/markelog/eclectica/versions"
@@ -51,25 +55,82 @@ var (
 	// Old version of python require older version of pip
 	withOldPip, _ = semver.Make("2.7.0")
 
-	bins = []string{"2to3", "idle", "pydoc", "python", "python-config", "pip", "easy_install"}
+	bins = []string{
+		"2to3", "idle", "pydoc", "python", "python-config", "pip", "easy_install",
+		"python3", "pip3",
+	}
 	dots = []string{".python-version"}
+
+	// Options of the "configure" for the variants of the version
+	variants = map[string][]string{
+		"t":       {"--disable-gil"},
+		"-debug":  {"--with-pydebug"},
+		"t-debug": {"--disable-gil", "--with-pydebug"},
+	}
+
+	// Suffixes variants might add to the names of the binaries, like "python3.13t",
+	// debug builds of the newer versions do not add anything
+	abiFlags = map[string][]string{
+		"t":       {"t"},
+		"-debug":  {"d", ""},
+		"t-debug": {"td", "t"},
+	}
 )
 
 // Python essential struct
 type Python struct {
 	pkg.Base
-	Version   string
-	Emitter   *emission.Emitter
-	waitGroup *sync.WaitGroup
+	Version    string
+	Emitter    *emission.Emitter
+	fromSource bool
+	waitGroup  *sync.WaitGroup
+}
+
+// Args is arguments struct for New() method
+type Args struct {
+	Version    string
+	Emitter    *emission.Emitter
+	FromSource bool
 }
 
-// New returns language struct
-func New(version string, emitter *emission.Emitter) *Python {
+// New returns struct of the implementation depending on the prefix of the version,
+// whether CPython is prebuilt or compiled is decided only before the download,
+// since that needs the network
+func New(args *Args) pkg.Pkg {
+	var (
+		version   = args.Version
+		emitter   = args.Emitter
+		flavor, _ = versions.SplitFlavor(version)
+	)
+
+	if strings.HasPrefix(flavor, "pypy") {
+		return pypy.New(version, emitter)
+	}
+
+	if flavor == "graalpy" {
+		return graalpy.New(version, emitter)
+	}
+
 	return &Python{
-		Version:   version,
-		Emitter:   emitter,
-		waitGroup: &sync.WaitGroup{},
+		Version:    version,
+		Emitter:    emitter,
+		fromSource: args.FromSource,
+		waitGroup:  &sync.WaitGroup{},
+	}
+}
+
+// Choose prebuilt CPython if there is one for the version and platform
+func (python Python) Choose() (pkg.Pkg, error) {
+	// For installed version it doesn't matter where it came from
+	if python.fromSource || variables.IsInstalled("python", python.Version) {
+		return &python, nil
+	}
+
+	if standalone.Has(python.Version) {
+		return standalone.New(python.Version, python.Emitter), nil
 	}
+
+	return &python, nil
 }
 
 // Events returns language related event emitter
@@ -103,6 +164,11 @@ func (python Python) Install() (err error) {
 		return
 	}
 
+	err = LinkVariant(python.Version)
+	if err != nil {
+		return
+	}
+
 	return python.renameLinks()
 }
 
@@ -111,7 +177,7 @@ func (python Python) PostInstall() (err error) {
 	path := variables.Path("python", python.Version)
 	bin := variables.GetBin("python", python.Version)
 
-	if hasTools(python.Version) {
+	if hasTools(sourceVersion(python.Version)) {
 		cmd, stderr, stdout, cmdErr := python.getCmd(
 			bin, []string{"-m", "ensurepip"},
 		)
@@ -148,8 +214,8 @@ func (python Python) PostInstall() (err error) {
 func (python Python) Info() map[string]string {
 	var (
 		result    = make(map[string]string)
-		version   = python.Version
-		chosen, _ = semver.Make(python.Version)
+		version   = sourceVersion(python.Version)
+		chosen, _ = semver.Make(version)
 
 		patch = strconv.Itoa(int(chosen.Patch))
 		minor = strconv.Itoa(int(chosen.Minor))
@@ -193,8 +259,41 @@ func (python Python) Dots() []string {
 	return dots
 }
 
-// ListRemote returns list of the all available remote versions
+// Normalize brings implementation without the version to its latest one,
+// like "pypy3.10" to "pypy3.10-latest"
+func (python Python) Normalize(version string) (string, error) {
+	return base.Python{}.Normalize(version)
+}
+
+// ReadVersion gets version from the ".python-version" file,
+// which might have the implementation prefix, like "pypy3.10-7.3.15"
+func (python Python) ReadVersion(path string) (string, error) {
+	return base.Python{}.ReadVersion(path)
+}
+
+// ListRemote returns list of the all available remote versions,
+// versions of other implementations are listed after the CPython ones
 func (python Python) ListRemote() (result []string, err error) {
+	result, err = python.listCPython()
+	if err != nil {
+		return
+	}
+
+	implementations := []pkg.Pkg{pypy.New("", nil), graalpy.New("", nil)}
+
+	for _, implementation := range implementations {
+		remotes, errList := implementation.ListRemote()
+		if errList != nil {
+			return nil, errList
+		}
+
+		result = append(result, remotes...)
+	}
+
+	return
+}
+
+func (python Python) listCPython() (result []string, err error) {
 	doc, err := goquery.NewDocument(VersionLink)
 
 	if err != nil {
@@ -358,16 +457,24 @@ func (python Python) getEnvs(original []string) (result []string) {
 	return
 }
 
-func (python Python) getLineArguments() []string {
+func (python Python) getLineArguments() (result []string) {
 	if runtime.GOOS == "darwin" {
-		return getOSXLineArguments(python.Version)
+		result = getOSXLineArguments(python.Version)
 	}
 
 	if runtime.GOOS == "linux" {
-		return getLinuxLineArguments(python.Version)
+		result = getLinuxLineArguments(python.Version)
 	}
 
-	return []string{}
+	return append(result, VariantArguments(python.Version)...)
+}
+
+// VariantArguments gets options of the "configure" for the variant
+// of the version, like "--disable-gil" for the free-threaded "3.13.1t"
+func VariantArguments(version string) []string {
+	_, variant := versions.SplitVariant(version)
+
+	return variants[variant]
 }
 
 func (python Python) getCmd(name string, args []string) (
@@ -419,9 +526,36 @@ func (python Python) externals() (err error) {
 	return
 }
 
+// LinkVariant links binary of the variant to the major version, like "python3",
+// if "make install" didn't, which is the case for the free-threaded variants
+func LinkVariant(version string) error {
+	source, variant := versions.SplitVariant(version)
+	if variant == "" {
+		return nil
+	}
+
+	bin := filepath.Join(variables.Path("python", version), "bin")
+
+	if _, err := os.Lstat(filepath.Join(bin, "python3")); err == nil {
+		return nil
+	}
+
+	chosen, _ := semver.Make(source)
+
+	for _, flags := range abiFlags[variant] {
+		name := fmt.Sprintf("python%d.%d%s", chosen.Major, chosen.Minor, flags)
+
+		if _, err := os.Stat(filepath.Join(bin, name)); err == nil {
+			return base.Link(version, name, "python3")
+		}
+	}
+
+	return errors.New("Can't find python binary of the \"" + variant + "\" variant")
+}
+
 // Since python 3.x versions are naming their binaries with 3 affix
 func (python Python) renameLinks() (err error) {
-	chosen, _ := semver.Make(python.Version)
+	chosen, _ := semver.Make(sourceVersion(python.Version))
 	if chosen.Major < 3 {
 		return nil
 	}
@@ -458,15 +592,18 @@ func (python Python) renameLinks() (err error) {
 }
 
 func (python Python) downloadExternals() (err error) {
-	chosen, _ := semver.Make(python.Version)
-	path := variables.Path("python", python.Version)
+	var (
+		source    = sourceVersion(python.Version)
+		chosen, _ = semver.Make(source)
+		path      = variables.Path("python", python.Version)
+	)
 
-	urls, err := patch.URLs(python.Version)
+	urls, err := patch.URLs(source)
 	if err != nil {
 		return errors.New(err)
 	}
 
-	if hasTools(python.Version) == false {
+	if hasTools(source) == false {
 		if chosen.LT(withOldPip) {
 			urls = append(urls, oldPipURL)
 		} else {
@@ -524,3 +661,10 @@ func hasTools(version string) bool {
 
 	return semverVersion.Compare(pipAvailable) != -1
 }
+
+// sourceVersion gets version of the sources variant was built from
+func sourceVersion(version string) string {
+	source, _ := versions.SplitVariant(version)
+
+	return source
+}
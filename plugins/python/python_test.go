@@ -3,15 +3,23 @@ package python_test
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 
 	"github.com/jarcoal/httpmock"
+	"github.com/markelog/monkey"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
 	eio "github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/pkg"
 	. "github.com/markelog/eclectica/plugins/python"
+	"github.com/markelog/eclectica/plugins/python/graalpy"
+	"github.com/markelog/eclectica/plugins/python/pypy"
+	"github.com/markelog/eclectica/plugins/python/standalone"
 	"github.com/markelog/eclectica/variables"
 )
 
@@ -23,18 +31,118 @@ var _ = Describe("python", func() {
 
 	python := &Python{}
 
+	Describe("New", func() {
+		old := standalone.VersionLink
+
+		AfterEach(func() {
+			standalone.VersionLink = old
+		})
+
+		It("should return PyPy for pypy versions", func() {
+			result := New(&Args{Version: "pypy3.10-7.3.15"})
+
+			Expect(result).To(BeAssignableToTypeOf(&pypy.PyPy{}))
+		})
+
+		It("should return GraalPy for graalpy versions", func() {
+			result := New(&Args{Version: "graalpy-24.0.0"})
+
+			Expect(result).To(BeAssignableToTypeOf(&graalpy.GraalPy{}))
+		})
+
+		It("should not look for prebuilt CPython", func() {
+			standalone.VersionLink = ""
+
+			Expect(New(&Args{Version: "3.12.1"})).To(BeAssignableToTypeOf(&Python{}))
+			Expect(New(&Args{})).To(BeAssignableToTypeOf(&Python{}))
+		})
+	})
+
+	Describe("Choose", func() {
+		var (
+			tmp string
+			old = standalone.VersionLink
+		)
+
+		choose := func(args *Args) pkg.Pkg {
+			result, err := New(args).(pkg.Chooser).Choose()
+			Expect(err).To(BeNil())
+
+			return result
+		}
+
+		BeforeEach(func() {
+			tmp, _ = ioutil.TempDir("", "eclectica-python")
+
+			monkey.Patch(variables.Cache, func() string {
+				return tmp
+			})
+
+			content := eio.Read("./standalone/testdata/releases.json")
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				io.WriteString(w, content)
+			}))
+
+			standalone.VersionLink = ts.URL
+		})
+
+		AfterEach(func() {
+			standalone.VersionLink = old
+
+			monkey.Unpatch(variables.Cache)
+			os.RemoveAll(tmp)
+		})
+
+		It("should prefer prebuilt CPython", func() {
+			Expect(choose(&Args{Version: "3.12.1"})).To(BeAssignableToTypeOf(&standalone.Standalone{}))
+		})
+
+		It("should compile CPython if asked to", func() {
+			Expect(choose(&Args{Version: "3.12.1", FromSource: true})).To(BeAssignableToTypeOf(&Python{}))
+		})
+
+		It("should compile CPython if there is no prebuilt one", func() {
+			Expect(choose(&Args{Version: "2.7.18"})).To(BeAssignableToTypeOf(&Python{}))
+		})
+
+		It("should compile variants of CPython", func() {
+			Expect(choose(&Args{Version: "3.13.1t"})).To(BeAssignableToTypeOf(&Python{}))
+		})
+	})
+
 	Describe("ListRemote", func() {
-		old := VersionLink
+		var (
+			old        = VersionLink
+			oldPyPy    = pypy.VersionLink
+			oldGraalPy = graalpy.VersionLink
+		)
 
 		AfterEach(func() {
 			VersionLink = old
+			pypy.VersionLink = oldPyPy
+			graalpy.VersionLink = oldGraalPy
 		})
 
 		Describe("success", func() {
 			BeforeEach(func() {
-				content := eio.Read("../../testdata/plugins/python/index.html")
+				var (
+					content        = eio.Read("../../testdata/plugins/python/index.html")
+					pypyContent    = eio.Read("./pypy/testdata/versions.json")
+					graalpyContent = eio.Read("./graalpy/testdata/tags.json")
+				)
 
 				ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path == "/pypy" {
+						io.WriteString(w, pypyContent)
+						return
+					}
+
+					if r.URL.Path == "/graalpy" {
+						io.WriteString(w, graalpyContent)
+						return
+					}
+
 					status := 200
 
 					if _, ok := r.URL.Query()["status"]; ok {
@@ -46,6 +154,8 @@ var _ = Describe("python", func() {
 				}))
 
 				VersionLink = ts.URL
+				pypy.VersionLink = ts.URL + "/pypy"
+				graalpy.VersionLink = ts.URL + "/graalpy"
 
 				remotes, err = python.ListRemote()
 			})
@@ -58,7 +168,17 @@ var _ = Describe("python", func() {
 				Expect(remotes[0]).To(Equal("3.7.3"))
 
 				last := len(remotes) - 1
-				Expect(remotes[last]).To(Equal("2.0.1"))
+				Expect(remotes[last]).To(Equal("graalpy-23.1.0"))
+			})
+
+			It("should list other implementations after CPython", func() {
+				Expect(remotes).To(ContainElement("pypy3.10-7.3.15"))
+
+				for i, remote := range remotes {
+					if remote == "pypy3.10-7.3.15" {
+						Expect(remotes[i-1]).To(Equal("2.0.1"))
+					}
+				}
 			})
 		})
 
@@ -144,5 +264,89 @@ var _ = Describe("python", func() {
 			Expect(result["filename"]).To(Equal("Python-3.3.0"))
 			Expect(result["url"]).To(Equal("https://www.python.org/ftp/python/3.3.0/Python-3.3.0.tgz"))
 		})
+
+		It("should get info about variant from its sources", func() {
+			result := (&Python{Version: "3.13.1t"}).Info()
+
+			Expect(result["filename"]).To(Equal("Python-3.13.1"))
+			Expect(result["url"]).To(Equal("https://www.python.org/ftp/python/3.13.1/Python-3.13.1.tgz"))
+		})
+	})
+
+	Describe("VariantArguments", func() {
+		It("should disable GIL for free-threaded variant", func() {
+			Expect(VariantArguments("3.13.1t")).To(Equal([]string{"--disable-gil"}))
+		})
+
+		It("should add debug options for debug variant", func() {
+			Expect(VariantArguments("3.12.1-debug")).To(Equal([]string{"--with-pydebug"}))
+		})
+
+		It("should combine options", func() {
+			Expect(VariantArguments("3.13.1t-debug")).To(Equal([]string{
+				"--disable-gil", "--with-pydebug",
+			}))
+		})
+
+		It("should not add anything for the usual version", func() {
+			Expect(VariantArguments("3.12.1")).To(BeEmpty())
+		})
+	})
+
+	Describe("LinkVariant", func() {
+		var tmp string
+
+		bin := func(version string) string {
+			return filepath.Join(tmp, "python", version, "bin")
+		}
+
+		BeforeEach(func() {
+			tmp, _ = ioutil.TempDir("", "eclectica-python-variant")
+
+			monkey.Patch(variables.Home, func() string {
+				return tmp
+			})
+		})
+
+		AfterEach(func() {
+			monkey.Unpatch(variables.Home)
+			os.RemoveAll(tmp)
+		})
+
+		It("should link free-threaded binary", func() {
+			os.MkdirAll(bin("3.13.1t"), 0755)
+			ioutil.WriteFile(filepath.Join(bin("3.13.1t"), "python3.13t"), []byte(""), 0755)
+
+			Expect(LinkVariant("3.13.1t")).To(BeNil())
+
+			target, _ := os.Readlink(filepath.Join(bin("3.13.1t"), "python3"))
+			Expect(target).To(Equal("python3.13t"))
+		})
+
+		It("should link debug binary without the suffix", func() {
+			os.MkdirAll(bin("3.12.1-debug"), 0755)
+			ioutil.WriteFile(filepath.Join(bin("3.12.1-debug"), "python3.12"), []byte(""), 0755)
+
+			Expect(LinkVariant("3.12.1-debug")).To(BeNil())
+
+			target, _ := os.Readlink(filepath.Join(bin("3.12.1-debug"), "python3"))
+			Expect(target).To(Equal("python3.12"))
+		})
+
+		It("should keep the link of \"make install\"", func() {
+			os.MkdirAll(bin("3.12.1-debug"), 0755)
+			ioutil.WriteFile(filepath.Join(bin("3.12.1-debug"), "python3.12d"), []byte(""), 0755)
+			os.Symlink("python3.12d", filepath.Join(bin("3.12.1-debug"), "python3"))
+
+			Expect(LinkVariant("3.12.1-debug")).To(BeNil())
+		})
+
+		It("should return an error if there is no binary of the variant", func() {
+			os.MkdirAll(bin("3.13.1t"), 0755)
+
+			Expect(LinkVariant("3.13.1t")).To(MatchError(
+				"Can't find python binary of the \"t\" variant",
+			))
+		})
 	})
 })
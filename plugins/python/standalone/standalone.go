@@ -0,0 +1,206 @@
+// Package standalone provides all needed logic for installation
+// of the relocatable prebuilt CPython from the python-build-standalone project
+package standalone
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/chuckpreslar/emission"
+	"github.com/go-errors/errors"
+
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/plugins/python/base"
+	"github.com/markelog/eclectica/request"
+	"github.com/markelog/eclectica/variables"
+	"github.com/markelog/eclectica/versions"
+)
+
+var (
+	// VersionLink is the URL of the releases from which we get all possible versions
+	VersionLink = "https://api.github.com/repos/astral-sh/python-build-standalone/releases"
+
+	// Every release has builds for all the supported versions,
+	// so only the recent ones are needed
+	perPage = 30
+
+	// Platforms as they are named in the archives
+	platforms = map[string]string{
+		"linux-amd64":  "x86_64-unknown-linux-gnu",
+		"linux-arm64":  "aarch64-unknown-linux-gnu",
+		"darwin-amd64": "x86_64-apple-darwin",
+		"darwin-arm64": "aarch64-apple-darwin",
+	}
+)
+
+// Standalone essential struct
+type Standalone struct {
+	base.Python
+}
+
+// asset is how GitHub API describes the archive of the release
+type asset struct {
+	Name   string `json:"name"`
+	URL    string `json:"browser_download_url"`
+	Digest string `json:"digest"`
+}
+
+// New returns language struct
+func New(version string, emitter *emission.Emitter) *Standalone {
+	return &Standalone{
+		Python: base.Python{
+			Version: version,
+			Emitter: emitter,
+		},
+	}
+}
+
+// Events returns language related event emitter
+func (standalone Standalone) Events() *emission.Emitter {
+	return standalone.Emitter
+}
+
+// PreDownload hook
+func (standalone Standalone) PreDownload() error {
+	if Has(standalone.Version) {
+		return nil
+	}
+
+	return errors.New("Incorrect version " + standalone.Version)
+}
+
+// Install hook
+func (standalone Standalone) Install() (err error) {
+	// Archives have only the binaries with the major version
+	err = base.Link(standalone.Version, "python3", "python")
+	if err != nil {
+		return
+	}
+
+	return base.Link(standalone.Version, "pip3", "pip")
+}
+
+// Info provides all the info needed for installation of the plugin
+func (standalone Standalone) Info() map[string]string {
+	var (
+		result = make(map[string]string)
+		item   = readAssets()[standalone.Version]
+	)
+
+	result["filename"] = strings.TrimSuffix(item.Name, ".tar.gz")
+	result["url"] = item.URL
+
+	// All archives have the same root folder
+	result["unarchive-filename"] = "python"
+
+	if strings.HasPrefix(item.Digest, "sha256:") {
+		result["sha256"] = strings.TrimPrefix(item.Digest, "sha256:")
+	}
+
+	return result
+}
+
+// ListRemote returns list of the versions which have prebuilt archive for the platform
+func (standalone Standalone) ListRemote() (result []string, err error) {
+	body, err := request.Body(fmt.Sprintf("%s?per_page=%d", VersionLink, perPage))
+	if err != nil {
+		return
+	}
+
+	releases := []struct {
+		Assets []asset `json:"assets"`
+	}{}
+
+	err = json.Unmarshal([]byte(body), &releases)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	var (
+		rAsset = getAssetPattern()
+		assets = map[string]asset{}
+	)
+
+	result = []string{}
+
+	// Releases go from the newest, so the latest build of the version wins
+	for _, release := range releases {
+		for _, item := range release.Assets {
+			match := rAsset.FindStringSubmatch(item.Name)
+			if len(match) == 0 {
+				continue
+			}
+
+			if _, ok := assets[match[1]]; ok {
+				continue
+			}
+
+			assets[match[1]] = item
+			result = append(result, match[1])
+		}
+	}
+
+	result = versions.Sort(result)
+
+	err = writeAssets(assets)
+
+	return
+}
+
+// Has checks if there is a prebuilt archive of the version for the platform,
+// versions are requested only if they are not known yet
+func Has(version string) bool {
+	if _, ok := readAssets()[version]; ok {
+		return true
+	}
+
+	_, err := New("", nil).ListRemote()
+	if err != nil {
+		return false
+	}
+
+	_, ok := readAssets()[version]
+
+	return ok
+}
+
+// getAssetPattern returns pattern for the archive names of the platform,
+// like "cpython-3.12.1+20240107-x86_64-unknown-linux-gnu-install_only.tar.gz"
+func getAssetPattern() *regexp.Regexp {
+	platform := platforms[runtime.GOOS+"-"+runtime.GOARCH]
+
+	return regexp.MustCompile(
+		`^cpython-(\d+\.\d+\.\d+)\+\d+-` + regexp.QuoteMeta(platform) + `-install_only\.tar\.gz$`,
+	)
+}
+
+func assetsPath() string {
+	return filepath.Join(variables.Cache(), "python-standalone")
+}
+
+// readAssets gets version to archive map saved by the last ListRemote()
+func readAssets() map[string]asset {
+	result := map[string]asset{}
+
+	json.Unmarshal([]byte(io.Read(assetsPath())), &result)
+
+	return result
+}
+
+func writeAssets(assets map[string]asset) error {
+	content, err := json.Marshal(assets)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	_, err = io.CreateDir(variables.Cache())
+	if err != nil {
+		return err
+	}
+
+	return io.WriteFile(assetsPath(), string(content))
+}
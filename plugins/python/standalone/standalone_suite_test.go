@@ -0,0 +1,13 @@
+package standalone_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestStandalone(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Standalone Suite")
+}
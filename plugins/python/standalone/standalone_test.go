@@ -0,0 +1,147 @@
+package standalone_test
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/markelog/monkey"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	eIO "github.com/markelog/eclectica/io"
+	. "github.com/markelog/eclectica/plugins/python/standalone"
+	"github.com/markelog/eclectica/variables"
+)
+
+var _ = Describe("standalone", func() {
+	var (
+		remotes []string
+		err     error
+		tmp     string
+		ts      *httptest.Server
+	)
+
+	standalone := New("", nil)
+	old := VersionLink
+
+	BeforeEach(func() {
+		tmp, _ = ioutil.TempDir("", "eclectica-standalone")
+
+		monkey.Patch(variables.Cache, func() string {
+			return tmp
+		})
+
+		monkey.Patch(variables.Home, func() string {
+			return tmp
+		})
+
+		content := eIO.Read("./testdata/releases.json")
+
+		ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, content)
+		}))
+
+		VersionLink = ts.URL
+	})
+
+	AfterEach(func() {
+		VersionLink = old
+		ts.Close()
+
+		monkey.Unpatch(variables.Cache)
+		monkey.Unpatch(variables.Home)
+		os.RemoveAll(tmp)
+	})
+
+	Describe("ListRemote", func() {
+		BeforeEach(func() {
+			remotes, err = standalone.ListRemote()
+		})
+
+		It("should not return an error", func() {
+			Expect(err).To(BeNil())
+		})
+
+		It("should list versions from all releases", func() {
+			Expect(remotes).To(Equal([]string{"3.12.1", "3.12.0", "3.11.7", "3.11.6"}))
+		})
+	})
+
+	Describe("Info", func() {
+		BeforeEach(func() {
+			standalone.ListRemote()
+		})
+
+		It("should get info about 3.12.1 version", func() {
+			result := New("3.12.1", nil).Info()
+
+			Expect(result["unarchive-filename"]).To(Equal("python"))
+
+			if runtime.GOOS == "linux" && runtime.GOARCH == "amd64" {
+				Expect(result["filename"]).To(Equal(
+					"cpython-3.12.1+20240107-x86_64-unknown-linux-gnu-install_only",
+				))
+				Expect(result["url"]).To(HaveSuffix(
+					"/20240107/cpython-3.12.1%2B20240107-x86_64-unknown-linux-gnu-install_only.tar.gz",
+				))
+				Expect(result["sha256"]).To(HaveLen(64))
+			}
+		})
+
+		It("should use the newest build of the version", func() {
+			result := New("3.11.7", nil).Info()
+
+			Expect(result["filename"]).To(ContainSubstring("+20240107-"))
+		})
+
+		It("should not have checksum if release doesn't provide it", func() {
+			result := New("3.12.0", nil).Info()
+
+			Expect(result).NotTo(HaveKey("sha256"))
+		})
+	})
+
+	Describe("Has", func() {
+		It("should request versions if they're not known", func() {
+			Expect(Has("3.12.1")).To(Equal(true))
+		})
+
+		It("should not have old versions", func() {
+			Expect(Has("2.7.18")).To(Equal(false))
+		})
+
+		It("should not have anything without the network", func() {
+			VersionLink = ""
+
+			Expect(Has("3.12.1")).To(Equal(false))
+		})
+	})
+
+	Describe("PreDownload", func() {
+		It("should return an error for unknown version", func() {
+			err := New("2.7.18", nil).PreDownload()
+
+			Expect(err.Error()).To(Equal("Incorrect version 2.7.18"))
+		})
+	})
+
+	Describe("Install", func() {
+		It("should link python3 as python", func() {
+			bin := filepath.Join(tmp, "python", "3.12.1", "bin")
+
+			os.MkdirAll(bin, 0755)
+			ioutil.WriteFile(filepath.Join(bin, "python3"), []byte(""), 0755)
+			ioutil.WriteFile(filepath.Join(bin, "pip3"), []byte(""), 0755)
+
+			Expect(New("3.12.1", nil).Install()).To(BeNil())
+
+			Expect(os.Readlink(filepath.Join(bin, "python"))).To(Equal("python3"))
+			Expect(os.Readlink(filepath.Join(bin, "pip"))).To(Equal("pip3"))
+		})
+	})
+})
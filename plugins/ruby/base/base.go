@@ -1,14 +1,33 @@
+// Package base provides the logic shared by all the Ruby plugins
 package base
 
 import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
 	"github.com/chuckpreslar/emission"
+	"github.com/go-errors/errors"
 
+	"github.com/markelog/eclectica/io"
 	"github.com/markelog/eclectica/pkg"
+	"github.com/markelog/eclectica/variables"
+	"github.com/markelog/eclectica/versions"
 )
 
 var (
-	bins = []string{"erb", "gem", "irb", "rake", "rdoc", "ri", "ruby"}
+	bins = []string{"bundle", "bundler", "erb", "gem", "irb", "rake", "rdoc", "ri", "ruby"}
 	dots = []string{".ruby-version"}
+
+	// Implementations are named with prefix, like "jruby-9.4.5.0"
+	implementations = map[string]bool{
+		"jruby":       true,
+		"truffleruby": true,
+	}
+
+	// Any number of version parts, since JRuby has four of them, or the keyword
+	rVersion = regexp.MustCompile(`^(\d+(\.\d+)*|latest)$`)
 )
 
 // Ruby is base struct for the rest of the Ruby plugin related structs
@@ -29,3 +48,61 @@ func (ruby Ruby) Bins() []string {
 func (ruby Ruby) Dots() []string {
 	return dots
 }
+
+// Normalize brings implementation without the version to its latest one,
+// like "jruby" to "jruby-latest"
+func (ruby Ruby) Normalize(version string) (string, error) {
+	if implementations[version] {
+		return versions.JoinFlavor(version, "latest"), nil
+	}
+
+	return version, nil
+}
+
+// ReadVersion gets version from the ".ruby-version" file,
+// which might have the implementation prefix, like "jruby-9.4.5.0"
+func (ruby Ruby) ReadVersion(path string) (string, error) {
+	content := strings.TrimSpace(io.Read(path))
+	if content == "" {
+		return "", nil
+	}
+
+	version := strings.TrimSpace(strings.Split(content, "\n")[0])
+
+	// ruby-build names TruffleRuby bundled with GraalVM like that,
+	// standalone one is the closest one we could install
+	version = strings.Replace(version, "truffleruby+graalvm-", "truffleruby-", 1)
+
+	flavor, rest := versions.SplitFlavor(version)
+	if implementations[flavor] && rVersion.MatchString(rest) {
+		return version, nil
+	}
+
+	// Like "ruby-3.2.2", which is the same as "3.2.2"
+	return io.ExtractVersion(version)
+}
+
+// Link creates links for the names in the bin folder of the version
+// if they don't exist, so "jruby" could be executed as "ruby"
+func Link(version, target string, names ...string) error {
+	bin := filepath.Join(variables.Path("ruby", version), "bin")
+
+	if _, err := os.Stat(filepath.Join(bin, target)); err != nil {
+		return errors.New(err)
+	}
+
+	for _, name := range names {
+		path := filepath.Join(bin, name)
+
+		if _, err := os.Lstat(path); err == nil {
+			continue
+		}
+
+		err := os.Symlink(target, path)
+		if err != nil {
+			return errors.New(err)
+		}
+	}
+
+	return nil
+}
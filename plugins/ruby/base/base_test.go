@@ -0,0 +1,46 @@
+package base_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/markelog/eclectica/plugins/ruby/base"
+)
+
+var _ = Describe("base", func() {
+	ruby := Ruby{}
+
+	Describe("ReadVersion", func() {
+		It("should read MRI version", func() {
+			Expect(ruby.ReadVersion("./testdata/mri/.ruby-version")).To(Equal("3.2.2"))
+		})
+
+		It("should drop the prefix of MRI", func() {
+			Expect(ruby.ReadVersion("./testdata/prefixed/.ruby-version")).To(Equal("3.2.2"))
+		})
+
+		It("should keep the implementation prefix with all version numbers", func() {
+			Expect(ruby.ReadVersion("./testdata/jruby/.ruby-version")).To(Equal("jruby-9.4.5.0"))
+		})
+
+		It("should drop the edition of TruffleRuby", func() {
+			Expect(ruby.ReadVersion("./testdata/truffleruby/.ruby-version")).To(Equal("truffleruby-23.1.2"))
+		})
+
+		It("should return nothing for empty file", func() {
+			Expect(ruby.ReadVersion("./testdata/empty/.ruby-version")).To(Equal(""))
+		})
+	})
+
+	Describe("Normalize", func() {
+		It("should use the latest version of the implementation", func() {
+			Expect(ruby.Normalize("jruby")).To(Equal("jruby-latest"))
+			Expect(ruby.Normalize("truffleruby")).To(Equal("truffleruby-latest"))
+		})
+
+		It("should not touch other versions", func() {
+			Expect(ruby.Normalize("3.2.2")).To(Equal("3.2.2"))
+			Expect(ruby.Normalize("jruby-9.4")).To(Equal("jruby-9.4"))
+		})
+	})
+})
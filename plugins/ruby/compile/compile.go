@@ -2,28 +2,26 @@
 package compile
 
 import (
-	"bufio"
 	"fmt"
-	"io"
 	"net"
 	"os"
 	"os/exec"
-	"path"
 	"path/filepath"
 	"regexp"
 	"runtime"
 	"strings"
-	"sync"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/chuckpreslar/emission"
 	"github.com/go-errors/errors"
-	"github.com/kr/pty"
 
-	"github.com/markelog/eclectica/console"
+	"github.com/markelog/eclectica/cmd/print"
+	eCompile "github.com/markelog/eclectica/compile"
 	eIO "github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/pkg"
 	"github.com/markelog/eclectica/plugins/ruby/base"
-	eStrings "github.com/markelog/eclectica/strings"
+	"github.com/markelog/eclectica/plugins/ruby/jruby"
+	"github.com/markelog/eclectica/plugins/ruby/truffleruby"
 	"github.com/markelog/eclectica/variables"
 	"github.com/markelog/eclectica/versions"
 )
@@ -39,17 +37,15 @@ var (
 // Ruby compile essential struct
 type Ruby struct {
 	base.Ruby
-	Version   string
-	Emitter   *emission.Emitter
-	waitGroup *sync.WaitGroup
+	Version string
+	Emitter *emission.Emitter
 }
 
 // New returns language struct
 func New(version string, emitter *emission.Emitter) *Ruby {
 	return &Ruby{
-		Version:   version,
-		Emitter:   emitter,
-		waitGroup: &sync.WaitGroup{},
+		Version: version,
+		Emitter: emitter,
 	}
 }
 
@@ -92,17 +88,24 @@ func (ruby Ruby) PreInstall() (err error) {
 
 // Install hook
 func (ruby Ruby) Install() (err error) {
-	err = ruby.configure()
+	source := eCompile.New(variables.InstallLanguage("ruby", ruby.Version), ruby.Emitter)
+
+	args, err := ruby.configureArgs()
+	if err != nil {
+		return
+	}
+
+	err = source.Configure(args...)
 	if err != nil {
 		return
 	}
 
-	err = ruby.prepare()
+	err = source.Prepare()
 	if err != nil {
 		return
 	}
 
-	return ruby.install()
+	return source.Install()
 }
 
 // PostInstall hook
@@ -125,8 +128,34 @@ func (ruby Ruby) Info() map[string]string {
 	return result
 }
 
-// ListRemote returns list of the all available remote versions
-func (ruby Ruby) ListRemote() ([]string, error) {
+// ListRemote returns list of the all available remote versions,
+// versions of other implementations are listed after the MRI ones
+func (ruby Ruby) ListRemote() (result []string, err error) {
+	result, err = ruby.listMRI()
+	if err != nil {
+		return
+	}
+
+	implementations := map[string]pkg.Pkg{
+		"jruby":       jruby.New("", nil),
+		"truffleruby": truffleruby.New("", nil),
+	}
+
+	// Other implementations should not break the list of the MRI versions
+	for name, implementation := range implementations {
+		remotes, errList := implementation.ListRemote()
+		if errList != nil {
+			print.Warning("Can't list "+name+" versions: "+errList.Error(), "")
+			continue
+		}
+
+		result = append(result, remotes...)
+	}
+
+	return
+}
+
+func (ruby Ruby) listMRI() ([]string, error) {
 	doc, err := goquery.NewDocument(VersionLink + "/")
 
 	if err != nil {
@@ -163,140 +192,6 @@ func (ruby Ruby) ListRemote() ([]string, error) {
 	return result, nil
 }
 
-func (ruby Ruby) configure() (err error) {
-	ruby.Emitter.Emit("configure")
-
-	cmd, stderr, stdout, err := ruby.configureArgs()
-	if err != nil {
-		return
-	}
-
-	ruby.listen("configure", stderr, false)
-	ruby.listen("configure", stdout, true)
-
-	err = cmd.Start()
-	if err != nil {
-		return console.Error(err, stderr, stdout)
-	}
-
-	cmd.Wait()
-
-	return
-}
-
-func (ruby Ruby) prepare() (err error) {
-	ruby.Emitter.Emit("prepare")
-
-	cmd, stderr, stdout, err := ruby.getCmd("make", "-j")
-	if err != nil {
-		return
-	}
-
-	ruby.listen("prepare", stderr, false)
-	ruby.listen("prepare", stdout, true)
-
-	err = cmd.Start()
-	if err != nil {
-		return console.Error(err, stderr, stdout)
-	}
-
-	ruby.waitGroup.Wait()
-	cmd.Wait()
-
-	return
-}
-
-func (ruby Ruby) install() (err error) {
-	ruby.Emitter.Emit("install")
-
-	cmd, stderr, stdout, err := ruby.getCmd("make", "install", "-j")
-	if err != nil {
-		return
-	}
-
-	ruby.listen("install", stderr, false)
-	ruby.listen("install", stdout, true)
-
-	err = cmd.Start()
-	if err != nil {
-		return console.Error(err, stderr, stdout)
-	}
-
-	ruby.waitGroup.Wait()
-	cmd.Wait()
-
-	return
-}
-
-func (ruby Ruby) listen(event string, pipe io.ReadCloser, emit bool) {
-	if pipe == nil {
-		return
-	}
-
-	scanner := bufio.NewScanner(pipe)
-
-	ruby.waitGroup.Add(1)
-	go func() {
-		defer ruby.waitGroup.Done()
-
-		if emit == false {
-			for scanner.Scan() {
-			}
-
-			return
-		}
-
-		for scanner.Scan() {
-			line := strings.TrimSpace(scanner.Text())
-			if len(line) == 0 {
-				continue
-			}
-
-			line = eStrings.ElipsisForTerminal(line)
-
-			ruby.Emitter.Emit(event, line)
-		}
-	}()
-}
-
-func (ruby Ruby) getCmd(args ...string) (
-	cmd *exec.Cmd,
-	stderr, stdout io.ReadCloser,
-	err error,
-) {
-	cmd = exec.Command(args[0], args[1:]...)
-
-	cmd.Env = append(os.Environ(), "LC_ALL=C") // Required for some reason
-	cmd.Dir = variables.InstallLanguage("ruby", ruby.Version)
-
-	stderr, err = cmd.StderrPipe()
-	if err != nil {
-		err = errors.New(err)
-		return
-	}
-
-	// In order to preserve colors output -
-	// trick the command into thinking this is real tty.
-	// Works properly only with "configure" command
-	if path.Base(args[0]) == "configure" {
-		stdout, tty, ptyErr := pty.Open()
-		if ptyErr != nil {
-			return nil, nil, nil, errors.New(ptyErr)
-		}
-		cmd.Stdout = tty
-		cmd.Stdin = tty
-
-		return cmd, stderr, stdout, nil
-	}
-
-	stdout, err = cmd.StdoutPipe()
-	if err != nil {
-		err = errors.New(err)
-		return
-	}
-	return
-}
-
 func remoteMap(version string) string {
 	proper := versions.Semverify(version)
 
@@ -307,17 +202,11 @@ func remoteMap(version string) string {
 	return version
 }
 
-func (ruby Ruby) configureArgs() (
-	cmd *exec.Cmd,
-	stdout, stderr io.ReadCloser,
-	err error,
-) {
+func (ruby Ruby) configureArgs() (args []string, err error) {
 	var (
-		path      = variables.InstallLanguage("ruby", ruby.Version)
-		configure = filepath.Join(path, "configure")
-		prefix    = "--prefix=" + variables.Path("ruby", ruby.Version)
-		baseruby  = "--with-baseruby="
-		shared    = "--enable-shared"
+		prefix   = "--prefix=" + variables.Path("ruby", ruby.Version)
+		baseruby = "--with-baseruby="
+		shared   = "--enable-shared"
 	)
 
 	bin, err := binRuby()
@@ -327,7 +216,7 @@ func (ruby Ruby) configureArgs() (
 	baseruby = baseruby + bin
 
 	if runtime.GOOS != "darwin" {
-		cmd, stdout, stderr, err = ruby.getCmd(configure, prefix, baseruby)
+		args = []string{prefix, baseruby}
 		return
 	}
 
@@ -340,14 +229,13 @@ func (ruby Ruby) configureArgs() (
 	opensslDir := "--with-openssl-dir=" + openssl
 	libyamlDir := "--with-libyaml-dir=" + libyaml
 
-	cmd, stdout, stderr, err = ruby.getCmd(
-		configure,
+	args = []string{
 		prefix,
 		baseruby,
 		libyamlDir,
 		opensslDir,
 		shared,
-	)
+	}
 	return
 }
 
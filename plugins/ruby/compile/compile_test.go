@@ -11,6 +11,8 @@ import (
 
 	eIO "github.com/markelog/eclectica/io"
 	. "github.com/markelog/eclectica/plugins/ruby/compile"
+	"github.com/markelog/eclectica/plugins/ruby/jruby"
+	"github.com/markelog/eclectica/plugins/ruby/truffleruby"
 	"github.com/markelog/eclectica/variables"
 )
 
@@ -23,19 +25,39 @@ var _ = Describe("compile ruby", func() {
 	ruby := &Ruby{}
 
 	Describe("ListRemote", func() {
-		old := VersionLink
+		var (
+			old            = VersionLink
+			oldJRuby       = jruby.VersionLink
+			oldTruffleRuby = truffleruby.VersionLink
+		)
 
 		AfterEach(func() {
 			VersionLink = old
+			jruby.VersionLink = oldJRuby
+			truffleruby.VersionLink = oldTruffleRuby
 		})
 
 		Describe("success", func() {
 			BeforeEach(func() {
-				content := eIO.Read("../../../testdata/plugins/ruby/compile-dist.html")
+				var (
+					content            = eIO.Read("../../../testdata/plugins/ruby/compile-dist.html")
+					jrubyContent       = eIO.Read("../jruby/testdata/maven-metadata.xml")
+					truffleRubyContent = eIO.Read("../truffleruby/testdata/tags.json")
+				)
 
 				// httpmock is not incompatible with goquery :/.
 				// See https://github.com/jarcoal/httpmock/issues/18
 				ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path == "/jruby" {
+						io.WriteString(w, jrubyContent)
+						return
+					}
+
+					if r.URL.Path == "/truffleruby" {
+						io.WriteString(w, truffleRubyContent)
+						return
+					}
+
 					status := 200
 
 					if _, ok := r.URL.Query()["status"]; ok {
@@ -47,6 +69,8 @@ var _ = Describe("compile ruby", func() {
 				}))
 
 				VersionLink = ts.URL
+				jruby.VersionLink = ts.URL + "/jruby"
+				truffleruby.VersionLink = ts.URL + "/truffleruby"
 
 				remotes, err = ruby.ListRemote()
 			})
@@ -63,6 +87,40 @@ var _ = Describe("compile ruby", func() {
 			It("should not contain preview version (2.5.0 in this case)", func() {
 				Expect(remotes).ToNot(ContainElement("2.5.0"))
 			})
+
+			It("should list other implementations", func() {
+				Expect(remotes).To(ContainElement("jruby-9.4.5.0"))
+				Expect(remotes).To(ContainElement("truffleruby-23.1.2"))
+			})
+		})
+
+		Describe("fail of other implementations", func() {
+			BeforeEach(func() {
+				content := eIO.Read("../../../testdata/plugins/ruby/compile-dist.html")
+
+				ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path == "/jruby" || r.URL.Path == "/truffleruby" {
+						w.WriteHeader(403)
+						return
+					}
+
+					io.WriteString(w, content)
+				}))
+
+				VersionLink = ts.URL
+				jruby.VersionLink = ts.URL + "/jruby"
+				truffleruby.VersionLink = ts.URL + "/truffleruby"
+
+				remotes, err = ruby.ListRemote()
+			})
+
+			It("should not return an error", func() {
+				Expect(err).To(BeNil())
+			})
+
+			It("should still list MRI versions", func() {
+				Expect(remotes).To(ContainElement("2.3.3"))
+			})
 		})
 
 		Describe("fail", func() {
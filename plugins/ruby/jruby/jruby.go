@@ -0,0 +1,119 @@
+// Package jruby provides all needed logic for installation of JRuby
+package jruby
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os/exec"
+	"regexp"
+
+	"github.com/chuckpreslar/emission"
+	"github.com/go-errors/errors"
+
+	"github.com/markelog/eclectica/plugins/ruby/base"
+	"github.com/markelog/eclectica/request"
+	"github.com/markelog/eclectica/versions"
+)
+
+var (
+	// VersionLink is the URL link from which we can get all possible versions
+	VersionLink = "https://repo1.maven.org/maven2/org/jruby/jruby-dist/maven-metadata.xml"
+
+	// DownloadLink from which we download binaries for jruby
+	DownloadLink = "https://repo1.maven.org/maven2/org/jruby/jruby-dist"
+
+	// Releases have four numbers, the rest are previews and release candidates
+	rVersion = regexp.MustCompile(`^\d+\.\d+\.\d+\.\d+$`)
+
+	// Archives come with the launchers for Windows too
+	rWindows = regexp.MustCompile(`\.(bat|exe|dll)$`)
+)
+
+// JRuby essential struct
+type JRuby struct {
+	base.Ruby
+}
+
+// metadata is how maven describes all the published versions
+type metadata struct {
+	Versions []string `xml:"versioning>versions>version"`
+}
+
+// New returns language struct
+func New(version string, emitter *emission.Emitter) *JRuby {
+	return &JRuby{
+		Ruby: base.Ruby{
+			Version: version,
+			Emitter: emitter,
+		},
+	}
+}
+
+// Events returns language related event emitter
+func (jruby JRuby) Events() *emission.Emitter {
+	return jruby.Emitter
+}
+
+// PreDownload hook
+func (jruby JRuby) PreDownload() error {
+	return HasJava()
+}
+
+// Install hook
+func (jruby JRuby) Install() error {
+	return base.Link(jruby.Version, "jruby", "ruby")
+}
+
+// IsBin checks if executable should be proxied
+func (jruby JRuby) IsBin(path string) bool {
+	return rWindows.MatchString(path) == false
+}
+
+// Info provides all the info needed for installation of the plugin
+func (jruby JRuby) Info() map[string]string {
+	var (
+		result  = make(map[string]string)
+		_, rest = versions.SplitFlavor(jruby.Version)
+	)
+
+	result["filename"] = fmt.Sprintf("jruby-dist-%s-bin", rest)
+	result["url"] = fmt.Sprintf("%s/%s/%s.tar.gz", DownloadLink, rest, result["filename"])
+	result["unarchive-filename"] = "jruby-" + rest
+
+	return result
+}
+
+// ListRemote returns list of the all available remote versions, like "jruby-9.4.5.0"
+func (jruby JRuby) ListRemote() (result []string, err error) {
+	body, err := request.Body(VersionLink)
+	if err != nil {
+		return
+	}
+
+	list := metadata{}
+	err = xml.Unmarshal([]byte(body), &list)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	result = []string{}
+	for _, version := range list.Versions {
+		if rVersion.MatchString(version) == false {
+			continue
+		}
+
+		result = append(result, versions.JoinFlavor("jruby", version))
+	}
+
+	return versions.Sort(result), nil
+}
+
+// HasJava checks if there is Java runtime JRuby could be executed with
+func HasJava() error {
+	err := exec.Command("java", "-version").Run()
+	if err != nil {
+		return errors.New("JRuby requires Java runtime, which is not available")
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package jruby_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestJRuby(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "JRuby Suite")
+}
@@ -0,0 +1,72 @@
+package jruby_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	eIO "github.com/markelog/eclectica/io"
+	. "github.com/markelog/eclectica/plugins/ruby/jruby"
+)
+
+var _ = Describe("jruby", func() {
+	var (
+		remotes []string
+		err     error
+	)
+
+	jruby := New("", nil)
+
+	Describe("ListRemote", func() {
+		old := VersionLink
+
+		BeforeEach(func() {
+			content := eIO.Read("./testdata/maven-metadata.xml")
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				io.WriteString(w, content)
+			}))
+
+			VersionLink = ts.URL
+
+			remotes, err = jruby.ListRemote()
+		})
+
+		AfterEach(func() {
+			VersionLink = old
+		})
+
+		It("should not return an error", func() {
+			Expect(err).To(BeNil())
+		})
+
+		It("should list releases with the implementation prefix", func() {
+			Expect(remotes).To(Equal([]string{
+				"jruby-10.0.0.0", "jruby-9.4.10.0", "jruby-9.4.5.0", "jruby-9.4.0.0", "jruby-9.3.13.0",
+			}))
+		})
+	})
+
+	Describe("Info", func() {
+		It("should get info about jruby-9.4.5.0 version", func() {
+			result := New("jruby-9.4.5.0", nil).Info()
+
+			Expect(result["filename"]).To(Equal("jruby-dist-9.4.5.0-bin"))
+			Expect(result["unarchive-filename"]).To(Equal("jruby-9.4.5.0"))
+			Expect(result["url"]).To(Equal(
+				"https://repo1.maven.org/maven2/org/jruby/jruby-dist/9.4.5.0/jruby-dist-9.4.5.0-bin.tar.gz",
+			))
+		})
+	})
+
+	Describe("IsBin", func() {
+		It("should not proxy launchers for Windows", func() {
+			Expect(jruby.IsBin("/jruby-9.4.5.0/bin/jruby.exe")).To(Equal(false))
+			Expect(jruby.IsBin("/jruby-9.4.5.0/bin/jruby.bat")).To(Equal(false))
+			Expect(jruby.IsBin("/jruby-9.4.5.0/bin/jruby")).To(Equal(true))
+		})
+	})
+})
@@ -7,13 +7,34 @@ import (
 	"github.com/chuckpreslar/emission"
 
 	"github.com/markelog/eclectica/pkg"
+	"github.com/markelog/eclectica/variables"
+	"github.com/markelog/eclectica/versions"
 
 	"github.com/markelog/eclectica/plugins/ruby/bin"
 	"github.com/markelog/eclectica/plugins/ruby/compile"
+	"github.com/markelog/eclectica/plugins/ruby/jruby"
+	"github.com/markelog/eclectica/plugins/ruby/truffleruby"
 )
 
-// New returns either compile or bin Ruby struct
+// New returns either compile or bin Ruby struct or the struct
+// of other implementation, depending on the prefix of the version
 func New(version string, emitter *emission.Emitter) pkg.Pkg {
+	flavor, _ := versions.SplitFlavor(version)
+
+	if flavor == "jruby" {
+		return jruby.New(version, emitter)
+	}
+
+	if flavor == "truffleruby" {
+		return truffleruby.New(version, emitter)
+	}
+
+	// Without the version there is nothing to look for and for installed version
+	// it doesn't matter where it came from, so don't bother the network
+	if version == "" || variables.IsInstalled("ruby", version) {
+		return compile.New(version, emitter)
+	}
+
 	if hasBin(version, emitter) {
 		return bin.New(version, emitter)
 	}
@@ -0,0 +1,138 @@
+// Package truffleruby provides all needed logic for installation of TruffleRuby
+package truffleruby
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+
+	"github.com/blang/semver"
+	"github.com/chuckpreslar/emission"
+	"github.com/go-errors/errors"
+
+	"github.com/markelog/eclectica/plugins/ruby/base"
+	"github.com/markelog/eclectica/request"
+	"github.com/markelog/eclectica/variables"
+	"github.com/markelog/eclectica/versions"
+)
+
+var (
+	// VersionLink is the URL link from which we can get all possible versions
+	VersionLink = "https://api.github.com/repos/oracle/truffleruby/tags"
+
+	// DownloadLink from which we download binaries for truffleruby
+	DownloadLink = "https://github.com/oracle/truffleruby/releases/download"
+
+	// Community archives are named consistently only from this version
+	minimalVersion, _ = semver.Make("23.1.0")
+
+	rVersion = regexp.MustCompile(`^graal-(\d+\.\d+\.\d+)$`)
+
+	// Architectures as they are named in the archives
+	archs = map[string]string{
+		"amd64": "amd64",
+		"arm64": "aarch64",
+	}
+)
+
+// TruffleRuby essential struct
+type TruffleRuby struct {
+	base.Ruby
+}
+
+// New returns language struct
+func New(version string, emitter *emission.Emitter) *TruffleRuby {
+	return &TruffleRuby{
+		Ruby: base.Ruby{
+			Version: version,
+			Emitter: emitter,
+		},
+	}
+}
+
+// Events returns language related event emitter
+func (truffleruby TruffleRuby) Events() *emission.Emitter {
+	return truffleruby.Emitter
+}
+
+// PreDownload hook
+func (truffleruby TruffleRuby) PreDownload() error {
+	_, err := getPlatform()
+
+	return err
+}
+
+// PostInstall hook
+func (truffleruby TruffleRuby) PostInstall() error {
+	// Recompiles the openssl extension against the libraries of the system
+	hook := filepath.Join(variables.Path("ruby", truffleruby.Version), "lib/truffle/post_install_hook.sh")
+
+	if _, err := os.Stat(hook); err != nil {
+		return nil
+	}
+
+	out, err := exec.Command(hook).CombinedOutput()
+	if err != nil {
+		return errors.New(string(out))
+	}
+
+	return nil
+}
+
+// Info provides all the info needed for installation of the plugin
+func (truffleruby TruffleRuby) Info() map[string]string {
+	var (
+		result      = make(map[string]string)
+		_, rest     = versions.SplitFlavor(truffleruby.Version)
+		platform, _ = getPlatform()
+	)
+
+	result["filename"] = fmt.Sprintf("truffleruby-community-%s-%s", rest, platform)
+	result["url"] = fmt.Sprintf("%s/graal-%s/%s.tar.gz", DownloadLink, rest, result["filename"])
+
+	return result
+}
+
+// ListRemote returns list of the all available remote versions, like "truffleruby-23.1.2"
+func (truffleruby TruffleRuby) ListRemote() (result []string, err error) {
+	tags, err := request.GitHubTags(VersionLink)
+	if err != nil {
+		return
+	}
+
+	result = []string{}
+	for _, tag := range tags {
+		match := rVersion.FindStringSubmatch(tag)
+		if len(match) == 0 {
+			continue
+		}
+
+		version, errParse := semver.Make(match[1])
+		if errParse != nil || version.LT(minimalVersion) {
+			continue
+		}
+
+		result = append(result, versions.JoinFlavor("truffleruby", match[1]))
+	}
+
+	return
+}
+
+// getPlatform returns platform as archives are named with it, like "linux-amd64"
+func getPlatform() (string, error) {
+	osName := runtime.GOOS
+
+	arch, ok := archs[runtime.GOARCH]
+	if ok == false {
+		return "", errors.New("Not supported architecture \"" + runtime.GOARCH + "\"")
+	}
+
+	if osName == "darwin" {
+		osName = "macos"
+	}
+
+	return osName + "-" + arch, nil
+}
@@ -0,0 +1,13 @@
+package truffleruby_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestTruffleRuby(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "TruffleRuby Suite")
+}
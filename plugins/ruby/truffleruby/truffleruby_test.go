@@ -0,0 +1,67 @@
+package truffleruby_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	eIO "github.com/markelog/eclectica/io"
+	. "github.com/markelog/eclectica/plugins/ruby/truffleruby"
+)
+
+var _ = Describe("truffleruby", func() {
+	var (
+		remotes []string
+		err     error
+	)
+
+	truffleruby := New("", nil)
+
+	Describe("ListRemote", func() {
+		old := VersionLink
+
+		BeforeEach(func() {
+			content := eIO.Read("./testdata/tags.json")
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				io.WriteString(w, content)
+			}))
+
+			VersionLink = ts.URL
+
+			remotes, err = truffleruby.ListRemote()
+		})
+
+		AfterEach(func() {
+			VersionLink = old
+		})
+
+		It("should not return an error", func() {
+			Expect(err).To(BeNil())
+		})
+
+		It("should list versions with the implementation prefix", func() {
+			Expect(remotes).To(Equal([]string{
+				"truffleruby-24.0.1", "truffleruby-24.0.0", "truffleruby-23.1.2",
+			}))
+		})
+	})
+
+	Describe("Info", func() {
+		It("should get info about truffleruby-23.1.2 version", func() {
+			result := New("truffleruby-23.1.2", nil).Info()
+
+			if runtime.GOOS == "linux" && runtime.GOARCH == "amd64" {
+				Expect(result["filename"]).To(Equal("truffleruby-community-23.1.2-linux-amd64"))
+				Expect(result["url"]).To(Equal(
+					"https://github.com/oracle/truffleruby/releases/download/" +
+						"graal-23.1.2/truffleruby-community-23.1.2-linux-amd64.tar.gz",
+				))
+			}
+		})
+	})
+})
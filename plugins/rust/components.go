@@ -0,0 +1,285 @@
+package rust
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/markelog/archive"
+	"gopkg.in/cavaliercoder/grab.v1"
+
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/request"
+	"github.com/markelog/eclectica/variables"
+)
+
+var (
+	// Tables and arrays of tables, only the last element of the latter is kept
+	rTable   = regexp.MustCompile(`^\[\[?([^\[\]]+)\]\]?$`)
+	rStrings = regexp.MustCompile(`"([^"]*)"`)
+)
+
+// Component is the archive of the toolchain part from the channel manifest
+type Component struct {
+	Name string
+	URL  string
+	Hash string
+}
+
+// Manifest is the channel manifest, like "channel-rust-1.75.0.toml",
+// which describes where to get all the parts of the toolchain
+type Manifest struct {
+	tables map[string]map[string]string
+}
+
+// GetManifest gets the channel manifest of the version
+func GetManifest(version string) (*Manifest, error) {
+	body, err := request.Body(fmt.Sprintf("%s/channel-rust-%s.toml", VersionLink, version))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Manifest{tables: readTOML(body)}, nil
+}
+
+// Component finds the archive of the component for the platform,
+// like "clippy", which manifest names "clippy-preview"
+func (manifest *Manifest) Component(name, platform string) (*Component, error) {
+	pkgName := name
+
+	if rename, ok := manifest.tables["renames."+name]; ok {
+		pkgName = tomlString(rename["to"])
+	}
+
+	// Some components are the same for all platforms, like "rust-src"
+	for _, target := range []string{platform, "*"} {
+		table, ok := manifest.tables["pkg."+pkgName+".target."+target]
+		if ok == false || tomlString(table["available"]) != "true" {
+			continue
+		}
+
+		return &Component{
+			Name: pkgName,
+			URL:  tomlString(table["url"]),
+			Hash: tomlString(table["hash"]),
+		}, nil
+	}
+
+	return nil, errors.New("Component \"" + name + "\" is not available")
+}
+
+// Target finds the archive of the standard library for the target,
+// like "wasm32-unknown-unknown"
+func (manifest *Manifest) Target(target string) (*Component, error) {
+	result, err := manifest.Component("rust-std", target)
+	if err != nil {
+		return nil, errors.New("Target \"" + target + "\" is not available")
+	}
+
+	// That's how installer names it
+	result.Name = "rust-std-" + target
+
+	return result, nil
+}
+
+// requested returns components and targets which were asked for
+// with the arguments or with the toolchain file of the project
+func (rust Rust) requested() (components, targets []string) {
+	components = append(components, rust.components...)
+	targets = append(targets, rust.targets...)
+
+	path, err := io.FindDotFile(toolchainFiles)
+	if err != nil || path == "" {
+		return
+	}
+
+	channel, fileComponents, fileTargets := readToolchain(path)
+
+	// Stable channel is whatever version the project uses
+	isStable := channel == "stable" || strings.HasPrefix(channel, "stable-")
+	isVersion := rust.Version == channel || strings.HasPrefix(rust.Version, channel+".")
+
+	// Toolchain file defines some other version
+	if isStable == false && isVersion == false {
+		return
+	}
+
+	components = append(components, fileComponents...)
+	targets = append(targets, fileTargets...)
+
+	return
+}
+
+// installComponents installs components and standard libraries
+// of the targets from the channel manifest to the prefix of the version
+func (rust Rust) installComponents(components, targets []string) error {
+	manifest, err := GetManifest(rust.Version)
+	if err != nil {
+		return err
+	}
+
+	platform, err := getPlatform()
+	if err != nil {
+		return err
+	}
+
+	items := []*Component{}
+
+	for _, name := range components {
+		item, err := manifest.Component(name, platform)
+		if err != nil {
+			return err
+		}
+
+		items = append(items, item)
+	}
+
+	for _, target := range targets {
+		item, err := manifest.Target(target)
+		if err != nil {
+			return err
+		}
+
+		items = append(items, item)
+	}
+
+	installed := rust.installed()
+
+	for _, item := range items {
+		if installed[item.Name] {
+			continue
+		}
+
+		err = rust.installComponent(item)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// installed returns components which installers of the version put in there
+func (rust Rust) installed() map[string]bool {
+	result := map[string]bool{}
+	path := filepath.Join(variables.Path("rust", rust.Version), "lib", "rustlib", "components")
+
+	for _, name := range strings.Split(io.Read(path), "\n") {
+		if name = strings.TrimSpace(name); name != "" {
+			result[name] = true
+		}
+	}
+
+	return result
+}
+
+// installComponent downloads the archive of the component and runs its installer
+func (rust Rust) installComponent(item *Component) error {
+	tmp := filepath.Join(variables.TempDir(), "rust-"+rust.Version+"-"+item.Name)
+
+	defer os.RemoveAll(tmp)
+
+	_, err := io.CreateDir(tmp)
+	if err != nil {
+		return err
+	}
+
+	response, err := grab.Get(tmp, item.URL)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	err = io.Verify(response.Filename, item.Hash, sha256.New())
+	if err != nil {
+		return err
+	}
+
+	err = archive.Extract(response.Filename, tmp)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	name := strings.TrimSuffix(filepath.Base(response.Filename), ".tar.gz")
+
+	return rust.runInstaller(filepath.Join(tmp, name, "install.sh"))
+}
+
+// readToolchain reads rustup toolchain file, legacy one has only the channel in it
+func readToolchain(path string) (channel string, components, targets []string) {
+	content := strings.TrimSpace(io.Read(path))
+
+	if strings.Contains(content, "[toolchain]") == false {
+		return strings.TrimSpace(strings.Split(content, "\n")[0]), nil, nil
+	}
+
+	toolchain := readTOML(content)["toolchain"]
+
+	channel = tomlString(toolchain["channel"])
+	components = tomlArray(toolchain["components"])
+	targets = tomlArray(toolchain["targets"])
+
+	return
+}
+
+// readTOML reads tables of the TOML file with the keys as they are,
+// it knows only what rust manifests and toolchain files have in them
+func readTOML(content string) map[string]map[string]string {
+	var (
+		result = map[string]map[string]string{"": {}}
+		table  = ""
+		lines  = strings.Split(content, "\n")
+	)
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if match := rTable.FindStringSubmatch(line); len(match) > 0 {
+			// Like [pkg.rust-src.target."*"]
+			table = strings.Replace(match[1], `"`, "", -1)
+
+			if _, ok := result[table]; ok == false {
+				result[table] = map[string]string{}
+			}
+
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		key := strings.Trim(strings.TrimSpace(parts[0]), `"`)
+		value := strings.TrimSpace(parts[1])
+
+		// Arrays might take more than one line
+		for strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]") == false && i+1 < len(lines) {
+			i++
+			value += " " + strings.TrimSpace(lines[i])
+		}
+
+		result[table][key] = value
+	}
+
+	return result
+}
+
+func tomlString(value string) string {
+	return strings.Trim(value, `"`)
+}
+
+func tomlArray(value string) (result []string) {
+	for _, match := range rStrings.FindAllStringSubmatch(value, -1) {
+		result = append(result, match[1])
+	}
+
+	return
+}
@@ -29,22 +29,40 @@ var (
 	versionPattern = "\\d+\\.\\d+(?:\\.\\d+)?(?:-(alpha|beta)(?:\\.\\d*)?)?"
 	listLink       = "https://github.com/rust-lang/rust.git"
 
-	bins = []string{"cargo", "rust-gdb", "rustc", "rustdoc"}
-	dots = []string{".rust-version"}
+	bins = []string{"cargo", "cargo-clippy", "rust-gdb", "rustc", "rustdoc", "rustfmt"}
+	dots = []string{".rust-version", "rust-toolchain.toml", "rust-toolchain"}
+
+	// Files of rustup which define the toolchain of the project
+	toolchainFiles = []string{"rust-toolchain.toml", "rust-toolchain"}
+
+	// Channels of rustup, like "stable" or "nightly-2024-01-01"
+	rChannel = regexp.MustCompile(`^(stable|beta|nightly)(-.+)?$`)
 )
 
 // Rust essential struct
 type Rust struct {
-	Version string
-	Emitter *emission.Emitter
+	Version    string
+	Emitter    *emission.Emitter
+	components []string
+	targets    []string
 	pkg.Base
 }
 
+// Args is arguments struct for New() method
+type Args struct {
+	Version    string
+	Emitter    *emission.Emitter
+	Components []string
+	Targets    []string
+}
+
 // New returns language struct
-func New(version string, emitter *emission.Emitter) *Rust {
+func New(args *Args) *Rust {
 	return &Rust{
-		Version: version,
-		Emitter: emitter,
+		Version:    args.Version,
+		Emitter:    args.Emitter,
+		components: args.Components,
+		targets:    args.Targets,
 	}
 }
 
@@ -55,9 +73,32 @@ func (rust Rust) Events() *emission.Emitter {
 
 // Install hook
 func (rust Rust) Install() error {
+	installer := filepath.Join(variables.Path("rust", rust.Version), "install.sh")
+
+	return rust.runInstaller(installer)
+}
+
+// PostInstall hook
+func (rust Rust) PostInstall() error {
+	return rust.Extend()
+}
+
+// Extend installs requested components and targets,
+// version might be already installed, then only the missing ones are
+func (rust Rust) Extend() error {
+	components, targets := rust.requested()
+
+	if len(components) == 0 && len(targets) == 0 {
+		return nil
+	}
+
+	return rust.installComponents(components, targets)
+}
+
+// runInstaller runs "install.sh" of the rust archive for the prefix of the version
+func (rust Rust) runInstaller(installer string) error {
 	path := variables.Path("rust", rust.Version)
 	tmp := filepath.Join(path, "tmp")
-	installer := filepath.Join(path, "install.sh")
 
 	// Just in case, tmp might not get removed if this method had an error
 	// before we could remove it
@@ -79,6 +120,14 @@ func (rust Rust) Install() error {
 	return err
 }
 
+// Environment returns list of the all needed envionment variables
+func (rust Rust) Environment() (result []string, err error) {
+	// So "cargo install" would put executables where proxies can find them
+	result = append(result, "CARGO_INSTALL_ROOT="+variables.Path("rust", rust.Version))
+
+	return
+}
+
 // Info provides all the info needed for installation of the plugin
 func (rust Rust) Info() map[string]string {
 	var (
@@ -106,6 +155,22 @@ func (rust Rust) Dots() []string {
 	return dots
 }
 
+// ReadVersion gets version from the ".rust-version" file or from the
+// "channel" of rustup toolchain file, which might be in the legacy format.
+// Channels are not versions, so such project uses the current one
+func (rust Rust) ReadVersion(path string) (string, error) {
+	if filepath.Base(path) == ".rust-version" {
+		return io.ReadVersion(path)
+	}
+
+	channel, _, _ := readToolchain(path)
+	if channel == "" || rChannel.MatchString(channel) {
+		return "", nil
+	}
+
+	return io.ExtractVersion(channel)
+}
+
 // ListRemote returns list of the all available remote versions
 func (rust Rust) ListRemote() ([]string, error) {
 	// Get stuff from git, since it's the only way to get it for rust.
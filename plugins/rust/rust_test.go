@@ -1,7 +1,10 @@
 package rust_test
 
 import (
+	goio "io"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -109,4 +112,178 @@ var _ = Describe("rust", func() {
 			}
 		})
 	})
+
+	Describe("Environment", func() {
+		It("should install crates to the folder of the version", func() {
+			result, err := (&Rust{Version: "1.75.0"}).Environment()
+
+			Expect(err).To(BeNil())
+			Expect(result).To(Equal([]string{
+				"CARGO_INSTALL_ROOT=" + variables.Path("rust", "1.75.0"),
+			}))
+		})
+	})
+
+	Describe("ReadVersion", func() {
+		rust := &Rust{}
+
+		It("should read channel of the toolchain file", func() {
+			Expect(rust.ReadVersion("./testdata/toolchain/rust-toolchain.toml")).To(Equal("1.75"))
+		})
+
+		It("should read legacy toolchain file", func() {
+			Expect(rust.ReadVersion("./testdata/legacy/rust-toolchain")).To(Equal("1.74.1"))
+		})
+
+		It("should ignore the channel name", func() {
+			Expect(rust.ReadVersion("./testdata/channel/rust-toolchain.toml")).To(Equal(""))
+		})
+	})
+
+	Describe("Manifest", func() {
+		var (
+			manifest *Manifest
+			err      error
+			old      = VersionLink
+		)
+
+		BeforeEach(func() {
+			content := Read("./testdata/channel-rust-1.75.0.toml")
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/channel-rust-1.75.0.toml" {
+					w.WriteHeader(404)
+					return
+				}
+
+				goio.WriteString(w, content)
+			}))
+
+			VersionLink = ts.URL
+
+			manifest, err = GetManifest("1.75.0")
+		})
+
+		AfterEach(func() {
+			VersionLink = old
+		})
+
+		It("should not return an error", func() {
+			Expect(err).To(BeNil())
+		})
+
+		It("should return an error for unknown version", func() {
+			_, err := GetManifest("0.0.1")
+
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should find renamed component", func() {
+			component, err := manifest.Component("clippy", "x86_64-unknown-linux-gnu")
+
+			Expect(err).To(BeNil())
+			Expect(component.Name).To(Equal("clippy-preview"))
+			Expect(component.URL).To(Equal(
+				"https://static.rust-lang.org/dist/2023-12-28/clippy-1.75.0-x86_64-unknown-linux-gnu.tar.gz",
+			))
+			Expect(component.Hash).To(Equal("3bd5f3bd9c1a6a2c4ed6b83bb4dbd2e9f9ab2a4ac1bcb1f4c2a4b0a1e4b7d1a2"))
+		})
+
+		It("should find component which is the same for all platforms", func() {
+			component, err := manifest.Component("rust-src", "x86_64-unknown-linux-gnu")
+
+			Expect(err).To(BeNil())
+			Expect(component.URL).To(Equal("https://static.rust-lang.org/dist/2023-12-28/rust-src-1.75.0.tar.gz"))
+		})
+
+		It("should return an error for unknown component", func() {
+			_, err := manifest.Component("rustfmt", "x86_64-unknown-linux-gnu")
+
+			Expect(err).To(MatchError("Component \"rustfmt\" is not available"))
+		})
+
+		It("should find standard library of the target", func() {
+			component, err := manifest.Target("wasm32-unknown-unknown")
+
+			Expect(err).To(BeNil())
+			Expect(component.Name).To(Equal("rust-std-wasm32-unknown-unknown"))
+			Expect(component.URL).To(Equal(
+				"https://static.rust-lang.org/dist/2023-12-28/rust-std-1.75.0-wasm32-unknown-unknown.tar.gz",
+			))
+		})
+
+		It("should return an error for unavailable target", func() {
+			_, err := manifest.Target("mips-unknown-linux-gnu")
+
+			Expect(err).To(MatchError("Target \"mips-unknown-linux-gnu\" is not available"))
+		})
+	})
+
+	Describe("Extend", func() {
+		var (
+			old        = VersionLink
+			components = filepath.Join(path, "rust", "1.75.0", "lib", "rustlib", "components")
+		)
+
+		BeforeEach(func() {
+			content := Read("./testdata/channel-rust-1.75.0.toml")
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				goio.WriteString(w, content)
+			}))
+
+			VersionLink = ts.URL
+
+			monkey.Patch(variables.Home, func() string {
+				return path
+			})
+
+			io.CreateDir(filepath.Dir(components))
+			io.WriteFile(components, "rustc\nclippy-preview\nrust-std-wasm32-unknown-unknown\n")
+		})
+
+		AfterEach(func() {
+			VersionLink = old
+
+			monkey.Unpatch(variables.Home)
+			os.RemoveAll(filepath.Join(path, "rust", "1.75.0"))
+		})
+
+		It("should not install components which are already installed", func() {
+			rust := New(&Args{
+				Version:    "1.75.0",
+				Components: []string{"clippy"},
+				Targets:    []string{"wasm32-unknown-unknown"},
+			})
+
+			Expect(rust.Extend()).To(BeNil())
+		})
+
+		It("should not do anything if nothing was requested", func() {
+			Expect(New(&Args{Version: "1.75.0"}).Extend()).To(BeNil())
+		})
+
+		It("should return an error for unavailable component", func() {
+			rust := New(&Args{
+				Version:    "1.75.0",
+				Components: []string{"rustfmt"},
+			})
+
+			Expect(rust.Extend()).To(MatchError("Component \"rustfmt\" is not available"))
+		})
+
+		It("should take components of the stable channel from the toolchain file", func() {
+			pwd, _ := os.Getwd()
+			defer os.Chdir(pwd)
+
+			os.Chdir("./testdata/stable")
+
+			rust := New(&Args{
+				Version:    "1.75.0",
+				Components: []string{"clippy"},
+			})
+
+			Expect(rust.Extend()).To(MatchError("Component \"rustfmt\" is not available"))
+		})
+	})
 })
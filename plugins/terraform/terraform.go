@@ -0,0 +1,61 @@
+// Package terraform provides all needed logic for installation of Terraform
+package terraform
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/chuckpreslar/emission"
+
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/plugins/hashicorp"
+)
+
+var (
+	dots = []string{".terraform-version", "*.tf"}
+
+	rRequired = regexp.MustCompile(`(?m)^\s*required_version\s*=\s*"([^"]*)"`)
+
+	// Exact version does not need to be resolved
+	rExact = regexp.MustCompile(`^=?\s*v?(\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?)$`)
+)
+
+// Terraform essential struct
+type Terraform struct {
+	*hashicorp.Tool
+}
+
+// New returns language struct
+func New(version string, emitter *emission.Emitter) *Terraform {
+	return &Terraform{
+		Tool: hashicorp.New("terraform", version, emitter),
+	}
+}
+
+// Dots returns list of the all available filenames
+// which can define versions
+func (terraform Terraform) Dots() []string {
+	return dots
+}
+
+// ReadVersion gets version from the ".terraform-version" file
+// or from the "required_version" constraint of the *.tf files
+func (terraform Terraform) ReadVersion(path string) (string, error) {
+	if filepath.Base(path) == ".terraform-version" {
+		return io.ReadVersion(path)
+	}
+
+	match := rRequired.FindStringSubmatch(io.Read(path))
+	if len(match) == 0 {
+		return "", nil
+	}
+
+	constraint := strings.TrimSpace(match[1])
+
+	if exact := rExact.FindStringSubmatch(constraint); len(exact) > 0 {
+		return exact[1], nil
+	}
+
+	return terraform.Resolve(constraint)
+}
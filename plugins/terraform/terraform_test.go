@@ -0,0 +1,103 @@
+package terraform_test
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/markelog/monkey"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	eIO "github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/plugins/hashicorp"
+	. "github.com/markelog/eclectica/plugins/terraform"
+	"github.com/markelog/eclectica/variables"
+)
+
+var _ = Describe("terraform", func() {
+	var (
+		tmp string
+		ts  *httptest.Server
+	)
+
+	terraform := New("", nil)
+	old := hashicorp.VersionLink
+
+	BeforeEach(func() {
+		tmp, _ = ioutil.TempDir("", "eclectica-terraform")
+
+		monkey.Patch(variables.Cache, func() string {
+			return tmp
+		})
+
+		monkey.Patch(variables.Prefix, func(name string) string {
+			return filepath.Join(tmp, name)
+		})
+
+		content := eIO.Read("./testdata/index.json")
+
+		ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, content)
+		}))
+
+		hashicorp.VersionLink = ts.URL
+	})
+
+	AfterEach(func() {
+		hashicorp.VersionLink = old
+		ts.Close()
+
+		monkey.Unpatch(variables.Cache)
+		monkey.Unpatch(variables.Prefix)
+		os.RemoveAll(tmp)
+	})
+
+	It("should look for the *.tf files", func() {
+		Expect(terraform.Dots()).To(Equal([]string{".terraform-version", "*.tf"}))
+	})
+
+	It("should have terraform binary", func() {
+		Expect(terraform.Bins()).To(Equal([]string{"terraform"}))
+	})
+
+	Describe("ReadVersion", func() {
+		It("should read .terraform-version", func() {
+			Expect(terraform.ReadVersion("./testdata/dot/.terraform-version")).To(Equal("1.6.6"))
+		})
+
+		It("should read exact \"required_version\"", func() {
+			Expect(terraform.ReadVersion("./testdata/exact/main.tf")).To(Equal("1.5.7"))
+		})
+
+		It("should resolve \"required_version\" constraint", func() {
+			terraform.ListRemote()
+
+			Expect(terraform.ReadVersion("./testdata/constraint/versions.tf")).To(Equal("1.6.6"))
+		})
+
+		It("should prefer installed version for the constraint", func() {
+			terraform.ListRemote()
+			os.MkdirAll(filepath.Join(tmp, "terraform", "1.5.7"), 0755)
+
+			Expect(terraform.ReadVersion("./testdata/constraint/versions.tf")).To(Equal("1.5.7"))
+		})
+
+		It("should return nothing if there is no \"required_version\"", func() {
+			Expect(terraform.ReadVersion("./testdata/none/main.tf")).To(Equal(""))
+		})
+
+		It("should find version in *.tf files up the tree", func() {
+			dir, _ := filepath.Abs("./testdata/exact")
+
+			version, path, err := eIO.FindVersion(terraform.Dots(), dir, terraform.ReadVersion)
+
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal("1.5.7"))
+			Expect(path).To(Equal(filepath.Join(dir, "main.tf")))
+		})
+	})
+})
@@ -0,0 +1,313 @@
+// Package zig provides all needed logic for installation of Zig
+package zig
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/chuckpreslar/emission"
+	"github.com/go-errors/errors"
+
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/pkg"
+	"github.com/markelog/eclectica/request"
+	"github.com/markelog/eclectica/variables"
+	"github.com/markelog/eclectica/versions"
+)
+
+var (
+	// VersionLink is the URL of the download index from which we get all possible versions
+	VersionLink = "https://ziglang.org/download/index.json"
+
+	// Names under which index provides latest development build
+	aliases = []string{"master", "nightly"}
+
+	bins = []string{"zig"}
+	dots = []string{".zig-version"}
+
+	rVersion = regexp.MustCompile(`^\d+\.\d+\.\d+(-[0-9A-Za-z.]+)?(\+[0-9A-Za-z]+)?$`)
+)
+
+// Zig essential struct
+type Zig struct {
+	Version string
+	Emitter *emission.Emitter
+	pkg.Base
+}
+
+// build is how index describes the archive for the platform
+type build struct {
+	Tarball string `json:"tarball"`
+	Shasum  string `json:"shasum"`
+}
+
+// New returns language struct
+func New(version string, emitter *emission.Emitter) *Zig {
+	return &Zig{
+		Version: version,
+		Emitter: emitter,
+	}
+}
+
+// Events returns language related event emitter
+func (zig Zig) Events() *emission.Emitter {
+	return zig.Emitter
+}
+
+// PreDownload hook
+func (zig Zig) PreDownload() error {
+	if _, ok := readIndex()[zig.Version]; ok {
+		return nil
+	}
+
+	// Index might be outdated or not there yet
+	_, err := zig.ListRemote()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := readIndex()[zig.Version]; ok == false {
+		return errors.New("Incorrect version " + zig.Version)
+	}
+
+	return nil
+}
+
+// Install hook
+func (zig Zig) Install() (err error) {
+	var (
+		base = variables.Path("zig", zig.Version)
+		bin  = filepath.Join(base, "bin")
+	)
+
+	// Already moved
+	if _, errStat := os.Stat(filepath.Join(bin, "zig")); errStat == nil {
+		return
+	}
+
+	_, err = io.CreateDir(bin)
+	if err != nil {
+		return
+	}
+
+	// Zig finds its "lib" folder by looking up from the binary
+	err = os.Rename(filepath.Join(base, "zig"), filepath.Join(bin, "zig"))
+	if err != nil {
+		return errors.New(err)
+	}
+
+	return
+}
+
+// Info provides all the info needed for installation of the plugin
+func (zig Zig) Info() map[string]string {
+	var (
+		result = make(map[string]string)
+		item   = readIndex()[zig.Version]
+	)
+
+	result["filename"] = strings.TrimSuffix(path.Base(item.Tarball), ".tar.xz")
+	result["extension"] = "tar.xz"
+	result["url"] = item.Tarball
+	result["sha256"] = item.Shasum
+
+	return result
+}
+
+// Bins returns list of the all bins included
+// with the distribution of the language
+func (zig Zig) Bins() []string {
+	return bins
+}
+
+// Dots returns list of the all available filenames
+// which can define versions
+func (zig Zig) Dots() []string {
+	return dots
+}
+
+// Normalize resolves "master" and "nightly" aliases to the latest development build
+func (zig Zig) Normalize(version string) (string, error) {
+	if isAlias(version) == false {
+		return version, nil
+	}
+
+	// Development build changes every day, so get the fresh index,
+	// but if it's not available the cached one will do
+	zig.ListRemote()
+
+	master := readMaster()
+	if master == "" {
+		return version, nil
+	}
+
+	return master, nil
+}
+
+// ReadVersion gets version from the ".zig-version" file,
+// development versions like "0.12.0-dev.2341+92211135f" are kept whole
+// and aliases are resolved to the development build known from the last index
+func (zig Zig) ReadVersion(path string) (string, error) {
+	lines := strings.Split(strings.TrimSpace(io.Read(path)), "\n")
+	version := strings.TrimSpace(lines[0])
+
+	if rVersion.MatchString(version) {
+		return version, nil
+	}
+
+	if isAlias(version) {
+		return zig.readAlias(version)
+	}
+
+	if version == "" {
+		return "", nil
+	}
+
+	return io.ReadVersion(path)
+}
+
+// readAlias resolves alias from the dot file, this happens on every call
+// of the proxy, so the index is requested only if it wasn't saved before
+func (zig Zig) readAlias(version string) (string, error) {
+	if master := readMaster(); master != "" {
+		return master, nil
+	}
+
+	_, err := zig.ListRemote()
+	if err != nil {
+		return "", err
+	}
+
+	master := readMaster()
+	if master == "" {
+		return "", errors.New("There is no development build for \"" + version + "\"")
+	}
+
+	return master, nil
+}
+
+// ListRemote returns list of the all available remote versions
+func (zig Zig) ListRemote() (result []string, err error) {
+	body, err := request.Body(VersionLink)
+	if err != nil {
+		return
+	}
+
+	data := map[string]json.RawMessage{}
+	err = json.Unmarshal([]byte(body), &data)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	var (
+		platform = getPlatform()
+		builds   = map[string]build{}
+		master   = ""
+	)
+
+	result = []string{}
+	for key, raw := range data {
+		entry := map[string]json.RawMessage{}
+		json.Unmarshal(raw, &entry)
+
+		version := key
+
+		// Development build is listed under "master" with its real version inside
+		if key == "master" {
+			json.Unmarshal(entry["version"], &version)
+			master = version
+		}
+
+		item := build{}
+		json.Unmarshal(entry[platform], &item)
+
+		// Not every version is available for every platform
+		if item.Tarball == "" {
+			continue
+		}
+
+		builds[version] = item
+		result = append(result, version)
+	}
+
+	// Index is an object, so there is no order
+	result = versions.Sort(result)
+
+	err = writeIndex(builds, master)
+
+	return
+}
+
+func isAlias(version string) bool {
+	for _, alias := range aliases {
+		if alias == version {
+			return true
+		}
+	}
+
+	return false
+}
+
+func indexPath() string {
+	return filepath.Join(variables.Cache(), "zig-index")
+}
+
+func masterPath() string {
+	return filepath.Join(variables.Cache(), "zig-master")
+}
+
+// readIndex gets version to archive map saved by the last ListRemote()
+func readIndex() map[string]build {
+	result := map[string]build{}
+
+	json.Unmarshal([]byte(io.Read(indexPath())), &result)
+
+	return result
+}
+
+// readMaster gets version of the development build saved by the last ListRemote()
+func readMaster() string {
+	return strings.TrimSpace(io.Read(masterPath()))
+}
+
+func writeIndex(builds map[string]build, master string) error {
+	content, err := json.Marshal(builds)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	_, err = io.CreateDir(variables.Cache())
+	if err != nil {
+		return err
+	}
+
+	err = io.WriteFile(indexPath(), string(content))
+	if err != nil {
+		return err
+	}
+
+	return io.WriteFile(masterPath(), master)
+}
+
+// getPlatform returns platform as index names it, like "x86_64-linux"
+func getPlatform() string {
+	var (
+		arch   = "x86_64"
+		osName = runtime.GOOS
+	)
+
+	if runtime.GOARCH == "arm64" {
+		arch = "aarch64"
+	}
+
+	if osName == "darwin" {
+		osName = "macos"
+	}
+
+	return arch + "-" + osName
+}
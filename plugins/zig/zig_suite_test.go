@@ -0,0 +1,13 @@
+package zig_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestZig(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Zig Suite")
+}
@@ -0,0 +1,179 @@
+package zig_test
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/markelog/monkey"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	eIO "github.com/markelog/eclectica/io"
+	. "github.com/markelog/eclectica/plugins/zig"
+	"github.com/markelog/eclectica/variables"
+)
+
+var _ = Describe("zig", func() {
+	var (
+		remotes []string
+		err     error
+		tmp     string
+		ts      *httptest.Server
+	)
+
+	zig := &Zig{}
+	old := VersionLink
+
+	BeforeEach(func() {
+		tmp, _ = ioutil.TempDir("", "eclectica-zig")
+
+		monkey.Patch(variables.Cache, func() string {
+			return tmp
+		})
+
+		content := eIO.Read("./testdata/index.json")
+
+		ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, content)
+		}))
+
+		VersionLink = ts.URL
+	})
+
+	AfterEach(func() {
+		VersionLink = old
+		ts.Close()
+
+		monkey.Unpatch(variables.Cache)
+		os.RemoveAll(tmp)
+	})
+
+	Describe("ListRemote", func() {
+		BeforeEach(func() {
+			remotes, err = zig.ListRemote()
+		})
+
+		It("should not return an error", func() {
+			Expect(err).To(BeNil())
+		})
+
+		It("should list versions available for the platform", func() {
+			Expect(remotes).To(Equal([]string{
+				"0.12.0-dev.2341+92211135f", "0.11.0", "0.10.1",
+			}))
+		})
+	})
+
+	Describe("Info", func() {
+		BeforeEach(func() {
+			zig.ListRemote()
+		})
+
+		It("should get info about 0.11.0 version", func() {
+			result := (&Zig{Version: "0.11.0"}).Info()
+
+			Expect(result["extension"]).To(Equal("tar.xz"))
+
+			if runtime.GOOS == "linux" && runtime.GOARCH == "amd64" {
+				Expect(result["filename"]).To(Equal("zig-linux-x86_64-0.11.0"))
+				Expect(result["url"]).To(Equal(
+					"https://ziglang.org/download/0.11.0/zig-linux-x86_64-0.11.0.tar.xz",
+				))
+				Expect(result["sha256"]).To(Equal(
+					"2d00e789fec4f71790a6e7bf83ff91d564943c5ee843c5fd966efc474b423047",
+				))
+			}
+		})
+
+		It("should get info about development version", func() {
+			result := (&Zig{Version: "0.12.0-dev.2341+92211135f"}).Info()
+
+			if runtime.GOOS == "linux" && runtime.GOARCH == "amd64" {
+				Expect(result["filename"]).To(Equal("zig-linux-x86_64-0.12.0-dev.2341+92211135f"))
+			}
+		})
+	})
+
+	Describe("PreDownload", func() {
+		It("should get the index if it's not known", func() {
+			err := (&Zig{Version: "0.11.0"}).PreDownload()
+
+			Expect(err).To(BeNil())
+		})
+
+		It("should return an error for unknown version", func() {
+			err := (&Zig{Version: "0.1.1"}).PreDownload()
+
+			Expect(err.Error()).To(Equal("Incorrect version 0.1.1"))
+		})
+	})
+
+	Describe("Normalize", func() {
+		It("should resolve \"master\" alias", func() {
+			Expect(zig.Normalize("master")).To(Equal("0.12.0-dev.2341+92211135f"))
+		})
+
+		It("should resolve \"nightly\" alias", func() {
+			Expect(zig.Normalize("nightly")).To(Equal("0.12.0-dev.2341+92211135f"))
+		})
+
+		It("should not touch other versions", func() {
+			Expect(zig.Normalize("0.11")).To(Equal("0.11"))
+		})
+	})
+
+	Describe("ReadVersion", func() {
+		It("should keep development version whole", func() {
+			version, err := zig.ReadVersion("./testdata/dev/.zig-version")
+
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal("0.12.0-dev.2341+92211135f"))
+		})
+
+		It("should extract release version", func() {
+			version, err := zig.ReadVersion("./testdata/release/.zig-version")
+
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal("0.11.0"))
+		})
+
+		It("should resolve alias with the index", func() {
+			path := filepath.Join(tmp, ".zig-version")
+			eIO.WriteFile(path, "master")
+
+			version, err := zig.ReadVersion(path)
+
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal("0.12.0-dev.2341+92211135f"))
+		})
+
+		It("should resolve alias without request if index is there", func() {
+			path := filepath.Join(tmp, ".zig-version")
+			eIO.WriteFile(path, "nightly")
+
+			zig.ListRemote()
+			VersionLink = ""
+
+			version, err := zig.ReadVersion(path)
+
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal("0.12.0-dev.2341+92211135f"))
+		})
+
+		It("should return an error if alias can't be resolved", func() {
+			path := filepath.Join(tmp, ".zig-version")
+			eIO.WriteFile(path, "master")
+
+			VersionLink = ""
+
+			_, err := zig.ReadVersion(path)
+
+			Expect(err).NotTo(BeNil())
+		})
+	})
+})
@@ -2,8 +2,11 @@
 package request
 
 import (
+	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"net/http"
+	"os"
 
 	"github.com/go-errors/errors"
 
@@ -11,6 +14,10 @@ import (
 )
 
 var (
+	// GitHubToken is the name of the environment variable with the token for
+	// GitHub API, without it only 60 requests per hour are allowed
+	GitHubToken = "GITHUB_TOKEN"
+
 	client = &http.Client{}
 )
 
@@ -21,6 +28,32 @@ func Body(url string) (string, error) {
 		return "", err
 	}
 
+	return read(response)
+}
+
+// gitHubBody gets body response from GitHub API, with the token if there is one
+func gitHubBody(url string) (string, error) {
+	token := os.Getenv(GitHubToken)
+	if token == "" {
+		return Body(url)
+	}
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	response, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+
+	return read(response)
+}
+
+func read(response *http.Response) (string, error) {
 	if response.StatusCode != 200 {
 		return "", errors.New(variables.ConnectionError)
 	}
@@ -35,3 +68,51 @@ func Body(url string) (string, error) {
 
 	return string(contents), nil
 }
+
+// Exists checks if there is anything to download from the url
+func Exists(url string) (bool, error) {
+	response, err := client.Head(url)
+	if err != nil {
+		return false, errors.New(variables.ConnectionError)
+	}
+
+	response.Body.Close()
+
+	return response.StatusCode == 200, nil
+}
+
+// GitHubTags gets names of all the tags from the GitHub API link
+// like "https://api.github.com/repos/denoland/deno/tags", page by page.
+// Some repositories have hundreds of tags, so set the token to avoid rate limit
+func GitHubTags(link string) ([]string, error) {
+	var (
+		result  = []string{}
+		perPage = 100
+	)
+
+	for page := 1; ; page++ {
+		body, err := gitHubBody(fmt.Sprintf("%s?per_page=%d&page=%d", link, perPage, page))
+		if err != nil {
+			return nil, err
+		}
+
+		tags := []struct {
+			Name string `json:"name"`
+		}{}
+
+		err = json.Unmarshal([]byte(body), &tags)
+		if err != nil {
+			return nil, errors.New(err)
+		}
+
+		for _, tag := range tags {
+			result = append(result, tag.Name)
+		}
+
+		if len(tags) < perPage {
+			break
+		}
+	}
+
+	return result, nil
+}
@@ -2,7 +2,9 @@ package request_test
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"os"
 	"reflect"
 
 	"github.com/jarcoal/httpmock"
@@ -96,4 +98,115 @@ var _ = Describe("request", func() {
 			})
 		})
 	})
+
+	Describe("Exists", func() {
+		BeforeEach(func() {
+			httpmock.Activate()
+
+			httpmock.RegisterResponder(
+				"HEAD",
+				"https://somewhere/there.zip",
+				httpmock.NewStringResponder(200, ""),
+			)
+
+			httpmock.RegisterResponder(
+				"HEAD",
+				"https://somewhere/nope.zip",
+				httpmock.NewStringResponder(404, ""),
+			)
+		})
+
+		AfterEach(func() {
+			defer httpmock.DeactivateAndReset()
+		})
+
+		It("should be true if there is something", func() {
+			exists, err := Exists("https://somewhere/there.zip")
+
+			Expect(err).To(BeNil())
+			Expect(exists).To(Equal(true))
+		})
+
+		It("should be false if there is nothing", func() {
+			exists, err := Exists("https://somewhere/nope.zip")
+
+			Expect(err).To(BeNil())
+			Expect(exists).To(Equal(false))
+		})
+	})
+
+	Describe("GitHubTags", func() {
+		BeforeEach(func() {
+			httpmock.Activate()
+
+			page := "["
+			for i := 0; i < 100; i++ {
+				if i > 0 {
+					page += ","
+				}
+
+				page += fmt.Sprintf(`{"name": "v1.%d.0"}`, i)
+			}
+			page += "]"
+
+			httpmock.RegisterResponder(
+				"GET",
+				"https://somewhere/tags?per_page=100&page=1",
+				httpmock.NewStringResponder(200, page),
+			)
+
+			httpmock.RegisterResponder(
+				"GET",
+				"https://somewhere/tags?per_page=100&page=2",
+				httpmock.NewStringResponder(200, `[{"name": "v2.0.0"}]`),
+			)
+		})
+
+		AfterEach(func() {
+			defer httpmock.DeactivateAndReset()
+		})
+
+		It("gets tags from all the pages", func() {
+			tags, err := GitHubTags("https://somewhere/tags")
+
+			Expect(err).To(BeNil())
+			Expect(tags).To(HaveLen(101))
+			Expect(tags).To(ContainElement("v1.0.0"))
+			Expect(tags).To(ContainElement("v2.0.0"))
+		})
+
+		It("returns an error if response is broken", func() {
+			httpmock.RegisterResponder(
+				"GET",
+				"https://somewhere/tags?per_page=100&page=2",
+				httpmock.NewStringResponder(200, "{"),
+			)
+
+			_, err := GitHubTags("https://somewhere/tags")
+
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("uses the token if there is one", func() {
+			authorization := ""
+
+			os.Setenv(GitHubToken, "secret")
+			defer os.Unsetenv(GitHubToken)
+
+			httpmock.RegisterResponder(
+				"GET",
+				"https://somewhere/tags?per_page=100&page=2",
+				func(req *http.Request) (*http.Response, error) {
+					authorization = req.Header.Get("Authorization")
+
+					return httpmock.NewStringResponse(200, "[]"), nil
+				},
+			)
+
+			_, err := GitHubTags("https://somewhere/tags")
+
+			Expect(err).To(BeNil())
+			Expect(authorization).To(Equal("Bearer secret"))
+		})
+	})
 })
@@ -7,6 +7,8 @@ import (
 	"runtime"
 	"strings"
 
+	"golang.org/x/crypto/ssh/terminal"
+
 	"github.com/markelog/eclectica/io"
 )
 
@@ -36,6 +38,16 @@ func IsDebug() bool {
 	return os.Getenv("EC_DEBUG") == "true"
 }
 
+// IsInteractive checks if eclectica can ask the user anything,
+// i.e. non-interactive mode wasn't requested and stdin is a terminal
+func IsInteractive() bool {
+	if os.Getenv("EC_NON_INTERACTIVE") == "true" {
+		return false
+	}
+
+	return terminal.IsTerminal(int(os.Stdin.Fd()))
+}
+
 // GetBin returns path to the bin folder of the provided language
 func GetBin(args ...interface{}) string {
 	name, version := nameAndVersion(args)
@@ -104,6 +116,26 @@ func Support() string {
 	return filepath.Join(Base(), "support")
 }
 
+// Proxy get path to the ec-proxy copy, every language proxy links to it
+func Proxy() string {
+	return filepath.Join(Support(), "ec-proxy")
+}
+
+// ProxyStamp get path to the stamp of the ec-proxy copy
+func ProxyStamp() string {
+	return filepath.Join(Support(), "ec-proxy.stamp")
+}
+
+// RehashStamp get path to the stamp of the ec-proxy binary proxies were rehashed for
+func RehashStamp() string {
+	return filepath.Join(Support(), "rehash.stamp")
+}
+
+// Cache get path to the folder with cached data
+func Cache() string {
+	return filepath.Join(Support(), "cache")
+}
+
 // InstallPath get path to install folder
 func InstallPath() string {
 	return filepath.Join(Support(), "install")
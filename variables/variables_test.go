@@ -38,4 +38,16 @@ var _ = Describe("variables", func() {
 			Expect(result).To(Equal("/test/.eclectica"))
 		})
 	})
+
+	Describe("IsInteractive", func() {
+		AfterEach(func() {
+			os.Unsetenv("EC_NON_INTERACTIVE")
+		})
+
+		It("should not be interactive if it was explicitly requested", func() {
+			os.Setenv("EC_NON_INTERACTIVE", "true")
+
+			Expect(variables.IsInteractive()).To(Equal(false))
+		})
+	})
 })
@@ -15,8 +15,83 @@ import (
 var (
 	majorPattern = `^\d+$`
 	rMajor       = regexp.MustCompile(majorPattern)
+
+	// Flavor might have version of the language it implements, like "pypy3.10-7.3.15"
+	flavorPattern = `^([a-z][a-z0-9]*(?:\.\d+)?)-(\d.*|latest)$`
+	rFlavor       = regexp.MustCompile(flavorPattern)
+
+	// Builds of the same version with different options, like
+	// free-threaded "3.13.1t" or "3.12.1-debug" python
+	variantPattern = `^(\d+(?:\.\d+){0,2})(t|-debug|t-debug)$`
+	rVariant       = regexp.MustCompile(variantPattern)
 )
 
+// SplitFlavor splits version like "temurin-21" to the flavor and the version itself,
+// flavor is empty for the versions of the default distribution of the language
+func SplitFlavor(version string) (flavor, rest string) {
+	match := rFlavor.FindStringSubmatch(version)
+	if len(match) == 0 {
+		return "", version
+	}
+
+	return match[1], match[2]
+}
+
+// JoinFlavor is the opposite of SplitFlavor
+func JoinFlavor(flavor, version string) string {
+	if flavor == "" {
+		return version
+	}
+
+	return flavor + "-" + version
+}
+
+// SplitVariant splits version like "3.13.1t" to the version itself and the variant
+// it was built with, variant is empty for the default builds
+func SplitVariant(version string) (rest, variant string) {
+	match := rVariant.FindStringSubmatch(version)
+	if len(match) == 0 {
+		return version, ""
+	}
+
+	return match[1], match[2]
+}
+
+// JoinVariant is the opposite of SplitVariant
+func JoinVariant(version, variant string) string {
+	return version + variant
+}
+
+// withVariant returns versions of the variant without the variant itself
+func withVariant(variant string, vers []string) []string {
+	result := []string{}
+
+	for _, version := range vers {
+		rest, current := SplitVariant(version)
+
+		if current == variant {
+			result = append(result, rest)
+		}
+	}
+
+	return result
+}
+
+// withFlavor returns versions of the flavor without the flavor itself
+func withFlavor(flavor string, vers []string) []string {
+	result := []string{}
+
+	for _, version := range vers {
+		current, rest := SplitFlavor(version)
+
+		if current == flavor {
+			result = append(result, rest)
+		}
+	}
+
+	return result
+}
+
 // Compose versions to map object of arrays from array
 func Compose(versions []string) map[string][]string {
 	majors := ComposeMajors(versions)
@@ -154,11 +229,18 @@ func Complete(version string, vers []string) (string, error) {
 
 // IsPartial checks if provided version is not full semver version
 func IsPartial(version string) bool {
+	_, version = SplitFlavor(version)
+
 	if version == "latest" {
 		return true
 	}
 
-	return len(strings.Split(version, ".")) != 3
+	// Prerelease and build parts might have dots too, like "0.12.0-dev.2341+92211135f"
+	version = strings.SplitN(version, "-", 2)[0]
+	version = strings.SplitN(version, "+", 2)[0]
+
+	// Some languages have four numbers in their versions, like JRuby "9.4.5.0"
+	return len(strings.Split(version, ".")) < 3
 }
 
 // HasMinor checks if provided version has minor info in it
@@ -186,6 +268,20 @@ func getLatest(versions map[string][]string) (string, error) {
 func Latest(version string, versions []string) (string, error) {
 	var vers map[string][]string
 
+	flavor, version := SplitFlavor(version)
+	version, variant := SplitVariant(version)
+
+	versions = withVariant(variant, withFlavor(flavor, versions))
+
+	// Restores what was split from the version
+	join := func(version string) string {
+		return JoinFlavor(flavor, JoinVariant(version, variant))
+	}
+
+	if len(versions) == 0 {
+		return "", errors.New("Incorrect version " + join(version))
+	}
+
 	if HasMinor(version) {
 		vers = ComposeMinors(versions)
 	} else {
@@ -193,36 +289,44 @@ func Latest(version string, versions []string) (string, error) {
 	}
 
 	if version == "latest" {
-		return getLatest(vers)
+		latest, err := getLatest(vers)
+
+		return join(latest), err
 	}
 
 	version = version + ".x"
 
 	if _, ok := vers[version]; ok == false {
-		return "", errors.New("Incorrect version " + version)
+		return "", errors.New("Incorrect version " + join(version))
 	}
 
 	result := GetElements(version, vers)
 
-	return result[0], nil
+	return join(result[0]), nil
 }
 
-// semverifyList semverifies the list of incomplete versions
+// semverifyList semverifies the list of incomplete versions and sorts it from the newest,
+// versions with four numbers can't be semver ones, so they are compared as they are
 func semverifyList(versions []string) []string {
-	semverList := []semver.Version{}
 	result := []string{}
+	parsed := map[string]*hversion.Version{}
 
 	for _, version := range versions {
-		parsed, _ := semver.Parse(Semverify(version))
-		semverList = append(semverList, parsed)
+		version = Semverify(version)
+
+		parsed[version], _ = hversion.NewVersion(version)
+		result = append(result, version)
 	}
 
-	semver.Sort(semverList)
+	sort.SliceStable(result, func(i, j int) bool {
+		first, second := parsed[result[i]], parsed[result[j]]
 
-	for i := len(semverList) - 1; i != -1; i-- {
-		element := semverList[i]
-		result = append(result, element.String())
-	}
+		if first == nil || second == nil {
+			return second == nil && first != nil
+		}
+
+		return first.GreaterThan(second)
+	})
 
 	return result
 }
@@ -259,3 +363,56 @@ func Unsemverify(version string) string {
 
 	return rp.ReplaceAllString(version, "$1")
 }
+
+// Sort sorts versions from newest to the oldest without changing them,
+// versions which can't be semverified are placed at the end.
+// Versions of the default flavor go first, then others by the flavor name
+func Sort(vers []string) []string {
+	result := make([]string, len(vers))
+	parsed := map[string]*hversion.Version{}
+	flavors := map[string]string{}
+
+	copy(result, vers)
+
+	for _, version := range result {
+		flavor, rest := SplitFlavor(version)
+		flavors[version] = flavor
+
+		rest, _ = SplitVariant(rest)
+
+		// Not semver parser, since there are versions like "9.4.5.0"
+		parsedVersion, err := hversion.NewVersion(Semverify(rest))
+		if err == nil {
+			parsed[version] = parsedVersion
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if flavors[result[i]] != flavors[result[j]] {
+			return flavors[result[i]] < flavors[result[j]]
+		}
+
+		first, second := parsed[result[i]], parsed[result[j]]
+
+		if first == nil || second == nil {
+			return second == nil && first != nil
+		}
+
+		return first.GreaterThan(second)
+	})
+
+	return result
+}
+
+// IsPrerelease checks if provided version is alpha, beta, rc and etc
+func IsPrerelease(version string) bool {
+	_, version = SplitFlavor(version)
+	version, _ = SplitVariant(version)
+
+	parsed, err := semver.Parse(Semverify(version))
+	if err != nil {
+		return false
+	}
+
+	return len(parsed.Pre) > 0
+}
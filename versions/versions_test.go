@@ -157,6 +157,130 @@ var _ = Describe("versions", func() {
 			Expect(err).To(BeNil())
 			Expect(test).To(Equal("6.1.1"))
 		})
+
+		It("should complete only versions of the same flavor", func() {
+			versions := []string{
+				"3.12.1", "3.10.4", "pypy-3.10.14", "pypy-3.9.19",
+			}
+
+			test, err := Complete("pypy-3", versions)
+
+			Expect(err).To(BeNil())
+			Expect(test).To(Equal("pypy-3.10.14"))
+
+			test, err = Complete("3", versions)
+
+			Expect(err).To(BeNil())
+			Expect(test).To(Equal("3.12.1"))
+		})
+
+		It("should return an error if there is no such flavor", func() {
+			_, err := Complete("zulu-21", []string{"temurin-21.0.2"})
+
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should complete only versions of the same variant", func() {
+			versions := []string{"3.13.1t", "3.13.0t", "3.13.2", "3.12.8-debug"}
+
+			test, err := Complete("3.13t", versions)
+
+			Expect(err).To(BeNil())
+			Expect(test).To(Equal("3.13.1t"))
+
+			test, err = Complete("3-debug", versions)
+
+			Expect(err).To(BeNil())
+			Expect(test).To(Equal("3.12.8-debug"))
+
+			_, err = Complete("3.12t", versions)
+
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should complete flavor with the version of the language", func() {
+			versions := []string{"pypy3.10-7.3.15", "pypy3.10-7.3.14", "pypy3.9-7.3.15"}
+
+			test, err := Complete("pypy3.10-latest", versions)
+
+			Expect(err).To(BeNil())
+			Expect(test).To(Equal("pypy3.10-7.3.15"))
+		})
+
+		It("should complete versions with four numbers", func() {
+			versions := []string{"jruby-9.4.5.0", "jruby-9.4.10.0", "jruby-9.3.13.0"}
+
+			test, err := Complete("jruby-9.4", versions)
+
+			Expect(err).To(BeNil())
+			Expect(test).To(Equal("jruby-9.4.10.0"))
+
+			test, err = Complete("jruby-9.4.5.0", versions)
+
+			Expect(err).To(BeNil())
+			Expect(test).To(Equal("jruby-9.4.5.0"))
+		})
+	})
+
+	Describe("SplitVariant", func() {
+		It("should split free-threaded variant", func() {
+			version, variant := SplitVariant("3.13.1t")
+
+			Expect(version).To(Equal("3.13.1"))
+			Expect(variant).To(Equal("t"))
+		})
+
+		It("should split debug variant of the partial version", func() {
+			version, variant := SplitVariant("3.12-debug")
+
+			Expect(version).To(Equal("3.12"))
+			Expect(variant).To(Equal("-debug"))
+		})
+
+		It("should not split prereleases and keywords", func() {
+			version, variant := SplitVariant("3.13.0-rc1")
+
+			Expect(version).To(Equal("3.13.0-rc1"))
+			Expect(variant).To(Equal(""))
+
+			version, variant = SplitVariant("latest")
+
+			Expect(version).To(Equal("latest"))
+			Expect(variant).To(Equal(""))
+		})
+
+		It("should join it back", func() {
+			Expect(JoinVariant("3.13.1", "t")).To(Equal("3.13.1t"))
+			Expect(JoinVariant("3.13.1", "")).To(Equal("3.13.1"))
+		})
+	})
+
+	Describe("SplitFlavor", func() {
+		It("should split flavor from the version", func() {
+			flavor, version := SplitFlavor("temurin-21.0.2")
+
+			Expect(flavor).To(Equal("temurin"))
+			Expect(version).To(Equal("21.0.2"))
+		})
+
+		It("should split flavor with the version of the language", func() {
+			flavor, version := SplitFlavor("pypy3.10-7.3.15")
+
+			Expect(flavor).To(Equal("pypy3.10"))
+			Expect(version).To(Equal("7.3.15"))
+		})
+
+		It("should not split prereleases", func() {
+			flavor, version := SplitFlavor("7.0.0-rc.1")
+
+			Expect(flavor).To(Equal(""))
+			Expect(version).To(Equal("7.0.0-rc.1"))
+		})
+
+		It("should join it back", func() {
+			Expect(JoinFlavor("pypy", "3.10")).To(Equal("pypy-3.10"))
+			Expect(JoinFlavor("", "3.10")).To(Equal("3.10"))
+		})
 	})
 
 	Describe("IsPartial", func() {
@@ -175,6 +299,20 @@ var _ = Describe("versions", func() {
 		It("Should return true for full version without minor", func() {
 			Expect(IsPartial("6")).To(Equal(true))
 		})
+
+		It("Should ignore the flavor", func() {
+			Expect(IsPartial("temurin-21")).To(Equal(true))
+			Expect(IsPartial("temurin-21.0.2")).To(Equal(false))
+		})
+
+		It("Should ignore prerelease and build parts", func() {
+			Expect(IsPartial("0.12.0-dev.2341+92211135f")).To(Equal(false))
+			Expect(IsPartial("0.12-dev.2341")).To(Equal(true))
+		})
+
+		It("Should return false for version with four numbers", func() {
+			Expect(IsPartial("jruby-9.4.5.0")).To(Equal(false))
+		})
 	})
 
 	Describe("Semverify", func() {
@@ -216,4 +354,49 @@ var _ = Describe("versions", func() {
 			Expect(Unsemverify("1.7.0-beta1")).To(Equal("1.7beta1"))
 		})
 	})
+
+	Describe("Sort", func() {
+		It("should sort versions from the newest without changing them", func() {
+			result := Sort([]string{"1.9", "1.10beta1", "1.10", "1.8.3"})
+
+			Expect(result).To(Equal([]string{"1.10", "1.10beta1", "1.9", "1.8.3"}))
+		})
+
+		It("should put unknown versions to the end", func() {
+			result := Sort([]string{"nightly", "1.2.3", "2.0.0"})
+
+			Expect(result).To(Equal([]string{"2.0.0", "1.2.3", "nightly"}))
+		})
+
+		It("should sort variants as their versions", func() {
+			result := Sort([]string{"3.12.1-debug", "3.13.1t", "3.13.2"})
+
+			Expect(result).To(Equal([]string{"3.13.2", "3.13.1t", "3.12.1-debug"}))
+		})
+
+		It("should sort versions with four numbers", func() {
+			result := Sort([]string{"jruby-9.4.5.0", "jruby-9.4.10.0", "jruby-9.3.13.0"})
+
+			Expect(result).To(Equal([]string{"jruby-9.4.10.0", "jruby-9.4.5.0", "jruby-9.3.13.0"}))
+		})
+
+		It("should group versions by the flavor", func() {
+			result := Sort([]string{"pypy-3.9.19", "3.10.4", "pypy-3.10.14", "3.12.1"})
+
+			Expect(result).To(Equal([]string{"3.12.1", "3.10.4", "pypy-3.10.14", "pypy-3.9.19"}))
+		})
+	})
+
+	Describe("IsPrerelease", func() {
+		It("should detect prerelease", func() {
+			Expect(IsPrerelease("1.10beta1")).To(Equal(true))
+			Expect(IsPrerelease("7.0.0-rc.1")).To(Equal(true))
+		})
+
+		It("should not detect stable versions", func() {
+			Expect(IsPrerelease("1.10")).To(Equal(false))
+			Expect(IsPrerelease("3.12.1-debug")).To(Equal(false))
+			Expect(IsPrerelease("6.4.0")).To(Equal(false))
+		})
+	})
 })